@@ -0,0 +1,188 @@
+package config
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/labstack/gommon/log"
+	"github.com/spf13/viper"
+)
+
+// clientProperties is an immutable snapshot of the client properties file. The client profiles are parsed once per
+// snapshot, on their first use, so that reading the config of a client on every call is a map read. A snapshot with
+// the config of the service resolves the profiles from its dynamic config first, see dynamicClientProperties.
+type clientProperties struct {
+	values          map[string]string
+	cnf             *Config
+	clients         sync.Map // client -> ClientConfig
+	circuitBreakers sync.Map // client -> CircuitBreakerClientConfig
+	retries         sync.Map // client -> RetryClientConfig
+}
+
+func newClientProperties(v *viper.Viper) *clientProperties {
+	props := &clientProperties{values: map[string]string{}}
+	if v == nil {
+		return props
+	}
+
+	for _, key := range v.AllKeys() {
+		props.values[key] = v.GetString(key)
+	}
+
+	return props
+}
+
+// clientNames returns the clients with an endpoint in the snapshot, in lower case like every key of the file
+func (p *clientProperties) clientNames() []string {
+	var clients []string
+
+	for key := range p.values {
+		if client, ok := strings.CutSuffix(key, EndPointSuffix); ok && client != "" {
+			clients = append(clients, client)
+		}
+	}
+
+	return clients
+}
+
+// get returns the value of the key, keys are case-insensitive like in viper
+func (p *clientProperties) get(key string) string {
+	return p.values[strings.ToLower(key)]
+}
+
+// getter returns a getter of the `<key><suffix>` configs of a client resolved from the dynamic config of the snapshot,
+// the environment variables, which do not change while the process runs, and the snapshot
+func (p *clientProperties) getter(key string) func(suffix string) string {
+	return func(suffix string) string {
+		return resolve(p.cnf, p, join(key, suffix))
+	}
+}
+
+// withDynamicConfig returns a snapshot of the same file values whose profiles are resolved with the config
+func (p *clientProperties) withDynamicConfig(cnf *Config) *clientProperties {
+	return &clientProperties{values: p.values, cnf: cnf}
+}
+
+func (p *clientProperties) clientConfig(client string) ClientConfig {
+	return memoize(&p.clients, client, func() ClientConfig { return parseClientConfig(client, p.getter(client)) })
+}
+
+func (p *clientProperties) circuitBreakerConfig(client string) CircuitBreakerClientConfig {
+	return memoize(&p.circuitBreakers, client, func() CircuitBreakerClientConfig {
+		return parseCircuitBreakerConfig(client, p.getter(client))
+	})
+}
+
+func (p *clientProperties) retryConfig(client string) RetryClientConfig {
+	return memoize(&p.retries, client, func() RetryClientConfig { return parseRetryConfig(client, p.getter(client)) })
+}
+
+func memoize[T any](cache *sync.Map, client string, parse func() T) T {
+	if val, ok := cache.Load(client); ok {
+		return val.(T)
+	}
+
+	val, _ := cache.LoadOrStore(client, parse())
+
+	return val.(T)
+}
+
+// clientPropertiesWatcher keeps the snapshot of the client properties file of a directory, a new snapshot replaces it
+// whenever the file changes, e.g. when its config map is updated
+type clientPropertiesWatcher struct {
+	snapshot atomic.Pointer[clientProperties]
+}
+
+// watchClientProperties reads the client properties file of the dir and watches it, when it cannot be read every client
+// uses its defaults
+func watchClientProperties(dir string) *clientPropertiesWatcher {
+	w := &clientPropertiesWatcher{}
+
+	v := viper.New()
+	v.SetConfigType(FileType)
+	v.AddConfigPath(dir)
+	v.SetConfigName(LocalConfigName)
+
+	if err := v.ReadInConfig(); err != nil {
+		log.Errorf("error in reading client properties file, using defaults ,Error: %v", err.Error())
+		w.snapshot.Store(newClientProperties(nil))
+
+		return w
+	}
+
+	w.snapshot.Store(newClientProperties(v))
+
+	// viper re-reads the file before calling the listener, on the goroutine of the watch
+	v.OnConfigChange(func(event fsnotify.Event) {
+		log.Infof("client properties file %s changed, reloading client configs", event.Name)
+		w.snapshot.Store(newClientProperties(v))
+	})
+	v.WatchConfig()
+
+	return w
+}
+
+func (w *clientPropertiesWatcher) load() *clientProperties {
+	return w.snapshot.Load()
+}
+
+//nolint:gochecknoglobals // the client properties file is read once per process and watched for changes
+var (
+	localPropertiesOnce    sync.Once
+	localPropertiesWatcher *clientPropertiesWatcher
+)
+
+// localClientProperties returns the snapshot of the client properties file, used when there is no dynamic config
+func localClientProperties() *clientProperties {
+	localPropertiesOnce.Do(func() {
+		localPropertiesWatcher = watchClientProperties(getConfigDirectory())
+	})
+
+	return localPropertiesWatcher.load()
+}
+
+// dynamicClientProperties is the snapshot of the profiles resolved with the dynamic config of a Config. App Config
+// refreshes its values every polling interval, so the profiles are resolved again once it elapses, or as soon as the
+// client properties file which they fall back to changes.
+type dynamicClientProperties struct {
+	props   *clientProperties
+	file    *clientProperties
+	expires time.Time
+}
+
+//nolint:gochecknoglobals // the config of the service is shared by every client
+var dynamicProperties sync.Map // *Config -> *dynamicClientProperties
+
+// clientPropertiesOf returns the snapshot which the client profiles of the config are memoized in
+func clientPropertiesOf(cnf *Config) *clientProperties {
+	file := localClientProperties()
+	if cnf.DynamicConfig == nil {
+		return file
+	}
+
+	now := time.Now()
+
+	if val, ok := dynamicProperties.Load(cnf); ok {
+		if current := val.(*dynamicClientProperties); current.file == file && now.Before(current.expires) {
+			return current.props
+		}
+	}
+
+	next := &dynamicClientProperties{props: file.withDynamicConfig(cnf), file: file, expires: now.Add(cnf.dynamicConfigRefresh())}
+	dynamicProperties.Store(cnf, next)
+
+	return next.props
+}
+
+// dynamicConfigRefresh returns how long the values of the dynamic config are kept, the polling interval of App Config
+// in seconds
+func (c *Config) dynamicConfigRefresh() time.Duration {
+	if c.AppConfig.PollingInterval <= 0 {
+		return DefaultDynamicConfigPollingIntervalSeconds * time.Second
+	}
+
+	return time.Duration(c.AppConfig.PollingInterval) * time.Second
+}
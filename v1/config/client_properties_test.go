@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchClientProperties(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, LocalConfigName+"."+FileType)
+
+	require.NoError(t, os.WriteFile(file, []byte("pageServiceConfig.endpoint=page:9090\npageServiceConfig.timeout=200\n"), 0o600))
+
+	w := watchClientProperties(dir)
+	props := w.load()
+
+	assert.Equal(t, ClientConfig{Endpoint: "page:9090", Timeout: 200 * time.Millisecond, Conn: 6000 * time.Millisecond},
+		props.clientConfig("pageServiceConfig"))
+	assert.Equal(t, DefaultRetryMaxRetries, props.retryConfig("pageServiceConfig").MaxRetries)
+	assert.Same(t, props, w.load(), "snapshot is kept until the file changes")
+	assert.Equal(t, []string{"pageserviceconfig"}, props.clientNames(), "keys of the file are case-insensitive")
+
+	require.NoError(t, os.WriteFile(file, []byte("pageServiceConfig.endpoint=page:9090\npageServiceConfig.timeout=300\n"), 0o600))
+
+	assert.Eventually(t, func() bool {
+		return w.load().clientConfig("pageServiceConfig").Timeout == 300*time.Millisecond
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 200*time.Millisecond, props.clientConfig("pageServiceConfig").Timeout, "snapshots are immutable")
+}
+
+func TestWatchClientPropertiesWithoutFile(t *testing.T) {
+	props := watchClientProperties(t.TempDir()).load()
+
+	assert.Equal(t, ClientConfig{Timeout: 6000 * time.Millisecond, Conn: 6000 * time.Millisecond}, props.clientConfig("pageServiceConfig"))
+	assert.Equal(t, DefaultCircuitBreakerDelayMs*time.Millisecond, props.circuitBreakerConfig("pageServiceConfig").Delay)
+}
+
+func TestClientPropertiesOfDynamicConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dc := NewMockDynamicConfig(ctrl)
+	cnf := &Config{DynamicConfig: dc, AppConfig: AppConfig{PollingInterval: 30}}
+
+	dc.EXPECT().Get("dynamic-client"+EndPointSuffix).Return("page:9090", nil).Times(1)
+	dc.EXPECT().Get("dynamic-client"+TimeoutSuffix).Return("200", nil).Times(1)
+	dc.EXPECT().Get("dynamic-client"+ConnTimeoutSuffix).Return("", nil).Times(1)
+
+	want := ClientConfig{Endpoint: "page:9090", Timeout: 200 * time.Millisecond, Conn: DefaultClientTimeoutMs * time.Millisecond}
+	assert.Equal(t, want, GetClientConfigs("dynamic-client", cnf))
+	assert.Equal(t, want, GetClientConfigs("dynamic-client", cnf), "profile is kept until the dynamic config refreshes")
+
+	val, ok := dynamicProperties.Load(cnf)
+	require.True(t, ok)
+
+	current := val.(*dynamicClientProperties)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), current.expires, time.Second)
+
+	// once the polling interval elapses the profile is resolved again
+	dynamicProperties.Store(cnf, &dynamicClientProperties{props: current.props, file: current.file, expires: time.Now()})
+
+	dc.EXPECT().Get("dynamic-client"+EndPointSuffix).Return("page:9091", nil).Times(1)
+	dc.EXPECT().Get("dynamic-client"+TimeoutSuffix).Return("300", nil).Times(1)
+	dc.EXPECT().Get("dynamic-client"+ConnTimeoutSuffix).Return("", nil).Times(1)
+
+	assert.Equal(t, ClientConfig{Endpoint: "page:9091", Timeout: 300 * time.Millisecond, Conn: DefaultClientTimeoutMs * time.Millisecond},
+		GetClientConfigs("dynamic-client", cnf))
+}
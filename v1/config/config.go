@@ -7,6 +7,7 @@ import (
 	dc "github.com/Allen-Career-Institute/go-kratos-commons/dynamicconfig/v1"
 	"github.com/labstack/gommon/log"
 	"github.com/spf13/viper"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -44,8 +45,67 @@ type CircuitBreakerClientConfig struct {
 }
 
 type RetryClientConfig struct {
-	MaxRetries int           // Number of retries
-	Delay      time.Duration // delay interval between each retry
+	MaxRetries       int           // Number of retries
+	Delay            time.Duration // delay interval between each retry
+	MaxDelay         time.Duration // max delay between retries, the delay is doubled after every retry until it reaches MaxDelay
+	JitterFactor     float32       // factor by which each delay is randomly varied, e.g. 0.2 varies a delay of 100ms between 80ms and 120ms
+	BudgetMaxTokens  float64       // size of the retry budget, retries are only attempted while more than half of the tokens are left
+	BudgetTokenRatio float64       // tokens added back to the retry budget for every successful call, every failed call removes one token
+	Methods          []string      // fully-qualified methods (e.g. /package.Service/Method) which are idempotent and may be retried
+}
+
+type HedgeClientConfig struct {
+	Delay       time.Duration // delay after which a second identical call is sent if the first one is not done, e.g. the p95 latency
+	BudgetRatio float64       // max ratio of the calls of the client which may be hedged
+	Methods     []string      // fully-qualified methods (e.g. /package.Service/Method) which are idempotent and may be hedged
+}
+
+type PoolClientConfig struct {
+	Size        int           // number of connections to the downstream, calls are spread over them in round-robin
+	IdleTimeout time.Duration // connections unused for longer are replaced on their next use, 0 disables it
+	MaxLifetime time.Duration // connections older than this are replaced on their next use, 0 disables it
+}
+
+type BulkheadClientConfig struct {
+	MaxConcurrency uint          // max number of calls in flight to the downstream, further calls wait for a permit, 0 disables the bulkhead
+	MaxWait        time.Duration // max time a call waits for a permit before it is rejected, 0 rejects it right away
+}
+
+type RateLimitClientConfig struct {
+	RPS   float64 // calls per second sent to the downstream, 0 disables the rate limit
+	Burst int     // max number of calls sent at once after an idle period
+	Mode  string  // RateLimitModeWait waits for a token up to the deadline of the call, RateLimitModeReject rejects the call right away
+}
+
+type CassetteClientConfig struct {
+	Mode string // CassetteModeRecord records the calls of the client to its cassette, CassetteModeReplay serves them from it
+	Dir  string // directory of the cassette files, one <client>.json file per client
+}
+
+type FallbackClientConfig struct {
+	Methods    []string      // fully-qualified read methods (e.g. /page.PageService/GetPageFromCache) served from their last good response
+	MaxEntries int           // max number of responses kept per client, the least recently used ones are evicted
+	MaxAge     time.Duration // responses older than this are not served, 0 serves them regardless of their age
+}
+
+type TLSClientConfig struct {
+	Mode       string // one of TLSModeInsecure, TLSModeTLS or TLSModeMTLS, when empty the credentials are derived from ENV and the endpoint
+	CAFile     string // PEM bundle of the CAs which sign the server certificate, the system roots are used when empty
+	CertFile   string // PEM client certificate, required with TLSModeMTLS
+	KeyFile    string // PEM client key, required with TLSModeMTLS
+	ServerName string // overrides the server name used to verify the server certificate
+}
+
+type DialClientConfig struct {
+	Resolver                     string        // one of ResolverDNS, ResolverPassthrough or ResolverStatic, when empty the endpoint is dialed as is
+	LBPolicy                     string        // one of LBPolicyPickFirst, LBPolicyRoundRobin or LBPolicyLeastRequest, pick first when empty
+	KeepaliveTime                time.Duration // interval of the keepalive pings on idle connections, 0 disables them
+	KeepaliveTimeout             time.Duration // time to wait for a keepalive ping ack before the connection is closed
+	KeepalivePermitWithoutStream bool          // send keepalive pings even when there are no calls in flight
+	MaxRecvMsgSize               int           // max size in bytes of the responses, the grpc default is used when 0
+	MaxSendMsgSize               int           // max size in bytes of the requests, the grpc default is used when 0
+	Compression                  string        // compressor of the requests, e.g. gzip, requests are not compressed when empty
+	ServiceConfig                string        // raw gRPC service config JSON, takes precedence over LBPolicy
 }
 
 type PlaylistConfig struct {
@@ -105,6 +165,9 @@ func LoadConfig() (*Config, error) {
 	var config Config
 
 	v := viper.New()
+	// environment variables override the files with the keys named by EnvKey, e.g. BFF_SERVER_PORT for server.port
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
 	v.AutomaticEnv()
 	v.AddConfigPath(getConfigDirectory())
 
@@ -121,6 +184,15 @@ func LoadConfig() (*Config, error) {
 		setEncryptionKeys(&config)
 	}
 
+	// invalid configs fail the startup outside of local runs, locally the defaults are used for the invalid values
+	if err := config.Validate(); err != nil {
+		if os.Getenv("ENV") != "" {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+
+		log.Warnf("invalid config, using defaults for the invalid values: %v", err)
+	}
+
 	return &config, nil
 }
 
@@ -186,6 +258,8 @@ func loadSubConfig(v *viper.Viper, config interface{}, name string) error {
 		return fmt.Errorf("error in reading %s config: %w", name, err)
 	}
 
+	recordFileSources(v)
+
 	return parseConfig(config, v)
 }
 
@@ -209,6 +283,10 @@ const (
 	FileType          = "properties"
 	LocalConfigName   = "client"
 	JwtSecretKey      = "jwt_secret"
+
+	DefaultClientTimeoutMs = 6000
+
+	DefaultDynamicConfigPollingIntervalSeconds = 60
 )
 
 const (
@@ -225,142 +303,180 @@ const (
 )
 
 const (
-	RetryMaxRetriesSuffix  = ".retry.max_retries"
-	RetryDelaySuffix       = ".retry.delay"
-	DefaultRetryMaxRetries = 3
-	DefaultRetryDelayMs    = 1000
+	RetryMaxRetriesSuffix        = ".retry.max_retries"
+	RetryDelaySuffix             = ".retry.delay"
+	RetryMaxDelaySuffix          = ".retry.max_delay"
+	RetryJitterFactorSuffix      = ".retry.jitter_factor"
+	RetryBudgetMaxTokensSuffix   = ".retry.budget_max_tokens"
+	RetryBudgetTokenRatioSuffix  = ".retry.budget_token_ratio"
+	RetryMethodsSuffix           = ".retry.methods"
+	DefaultRetryMaxRetries       = 3
+	DefaultRetryDelayMs          = 1000
+	DefaultRetryMaxDelayMs       = 5000
+	DefaultRetryJitterFactor     = 0.2
+	DefaultRetryBudgetMaxTokens  = 10
+	DefaultRetryBudgetTokenRatio = 0.1
+	BitSize64                    = 64
 )
 
 const (
-	Base10    = 10
-	BitSize32 = 32
+	HedgeDelaySuffix        = ".hedge.delay"
+	HedgeBudgetRatioSuffix  = ".hedge.budget_ratio"
+	HedgeMethodsSuffix      = ".hedge.methods"
+	DefaultHedgeDelayMs     = 100
+	DefaultHedgeBudgetRatio = 0.1
 )
 
 const (
-	ReadConfigErrorLog      = "error in reading server properties file config client: %v ,Error: %v"
-	StringToIntParsingError = "error parsing %s for client: %v ,Error: %v, using defaults"
-	DynConfigParsingError   = "error fetching %s aws config for client: %v ,Error: %v"
+	PoolSizeSuffix        = ".pool.size"
+	PoolIdleTimeoutSuffix = ".pool.idle_timeout"
+	PoolMaxLifetimeSuffix = ".pool.max_lifetime"
+	DefaultPoolSize       = 1
 )
 
-// nolint:gocognit //cannot be reduced further
-func GetClientConfigs(client string, cnf *Config) ClientConfig {
-	// Reading from server properties files
-	if cnf.DynamicConfig == nil {
-		v := viper.New()
-		v.SetConfigType(FileType)
-		v.AddConfigPath(getConfigDirectory())
-		v.SetConfigName(LocalConfigName)
-
-		if err := v.ReadInConfig(); err != nil {
-			log.Errorf(ReadConfigErrorLog, client, err.Error())
-		}
+const (
+	BulkheadMaxConcurrencySuffix = ".bulkhead.max_concurrency"
+	BulkheadMaxWaitSuffix        = ".bulkhead.max_wait"
+	DefaultBulkheadMaxWaitMs     = 50
+)
 
-		timeoutConfig := v.GetString(join(client, TimeoutSuffix))
+const (
+	RateLimitRPSSuffix   = ".ratelimit.rps"
+	RateLimitBurstSuffix = ".ratelimit.burst"
+	RateLimitModeSuffix  = ".ratelimit.mode"
+	RateLimitModeWait    = "wait"
+	RateLimitModeReject  = "reject"
+)
 
-		timeout, err := time.ParseDuration(join(timeoutConfig, TimeInMs))
-		if timeout == 0 || err != nil {
-			timeout = 6000 * time.Millisecond
-		}
+const (
+	FallbackMethodsSuffix     = ".fallback.methods"
+	FallbackMaxEntriesSuffix  = ".fallback.max_entries"
+	FallbackMaxAgeSuffix      = ".fallback.max_age"
+	DefaultFallbackMaxEntries = 1000
+)
 
-		connConfig := v.GetString(join(client, ConnTimeoutSuffix))
+const (
+	CassetteModeSuffix = ".cassette.mode"
+	CassetteDirSuffix  = ".cassette.dir"
+	CassetteModeRecord = "record"
+	CassetteModeReplay = "replay"
+	DefaultCassetteDir = "testdata/cassettes"
+)
 
-		conn, err := time.ParseDuration(join(connConfig, TimeInMs))
-		if conn == 0 || err != nil {
-			conn = 6000 * time.Millisecond
-		}
+const (
+	TLSModeSuffix       = ".tls.mode"
+	TLSCAFileSuffix     = ".tls.ca_file"
+	TLSCertFileSuffix   = ".tls.cert_file"
+	TLSKeyFileSuffix    = ".tls.key_file"
+	TLSServerNameSuffix = ".tls.server_name"
+	TLSModeInsecure     = "insecure"
+	TLSModeTLS          = "tls"
+	TLSModeMTLS         = "mtls"
+)
 
-		return ClientConfig{
-			Endpoint: v.GetString(join(client, EndPointSuffix)),
-			Timeout:  timeout,
-			Conn:     conn,
-		}
-	}
+const (
+	ResolverSuffix                     = ".resolver"
+	LBPolicySuffix                     = ".lb_policy"
+	KeepaliveTimeSuffix                = ".keepalive.time"
+	KeepaliveTimeoutSuffix             = ".keepalive.timeout"
+	KeepalivePermitWithoutStreamSuffix = ".keepalive.permit_without_stream"
+	MaxRecvMsgSizeSuffix               = ".max_recv_msg_size"
+	MaxSendMsgSizeSuffix               = ".max_send_msg_size"
+	CompressionSuffix                  = ".compression"
+	ServiceConfigSuffix                = ".service_config"
+	ResolverDNS                        = "dns"
+	ResolverPassthrough                = "passthrough"
+	ResolverStatic                     = "static"
+	LBPolicyPickFirst                  = "pick_first"
+	LBPolicyRoundRobin                 = "round_robin"
+	LBPolicyLeastRequest               = "least_request"
+	DefaultKeepaliveTimeoutMs          = 20000
+)
 
-	// Reading from aws App Config
-	connConfig, err := cnf.DynamicConfig.Get(client + ConnTimeoutSuffix)
-	if err != nil {
-		log.Errorf("error fetching conn aws config for client: %v ,Error: %v", client, err.Error())
-	}
+const (
+	Base10    = 10
+	BitSize32 = 32
+)
 
-	conn, err := time.ParseDuration(connConfig + TimeInMs)
-	if conn == 0 || err != nil {
-		conn = 6000 * time.Millisecond
-	}
+const (
+	ReadConfigErrorLog      = "error in reading server properties file config client: %v ,Error: %v"
+	StringToIntParsingError = "error parsing %s for client: %v ,Error: %v, using defaults"
+	DynConfigParsingError   = "error fetching %s aws config for client: %v ,Error: %v"
+)
 
-	timeoutConfig, err := cnf.DynamicConfig.Get(client + TimeoutSuffix)
-	if err != nil {
-		log.Errorf("error fetching timeout aws config for client: %v ,Error: %v", client, err.Error())
-	}
+func GetClientConfigs(client string, cnf *Config) ClientConfig {
+	// the client profiles are parsed once per snapshot of the client properties file and of the dynamic config
+	return clientPropertiesOf(cnf).clientConfig(client)
+}
 
-	timeout, err := time.ParseDuration(timeoutConfig + TimeInMs)
+func parseClientConfig(client string, get func(suffix string) string) ClientConfig {
+	timeout, err := time.ParseDuration(join(get(TimeoutSuffix), TimeInMs))
 	if timeout == 0 || err != nil {
-		timeout = 6000 * time.Millisecond
+		timeout = DefaultClientTimeoutMs * time.Millisecond
+		defaultSources.recordDefault(join(client, TimeoutSuffix), timeout)
 	}
 
-	endpoint, err := cnf.DynamicConfig.Get(client + EndPointSuffix)
-	if err != nil {
-		log.Errorf("error fetching endpoint aws config for client: %v ,Error: %v", client, err.Error())
+	conn, err := time.ParseDuration(join(get(ConnTimeoutSuffix), TimeInMs))
+	if conn == 0 || err != nil {
+		conn = DefaultClientTimeoutMs * time.Millisecond
+		defaultSources.recordDefault(join(client, ConnTimeoutSuffix), conn)
 	}
 
-	return ClientConfig{Endpoint: endpoint, Timeout: timeout, Conn: conn}
+	return ClientConfig{
+		Endpoint: get(EndPointSuffix),
+		Timeout:  timeout,
+		Conn:     conn,
+	}
 }
 
 func GetCircuitBreakerClientConfigs(client string, cnf *Config) CircuitBreakerClientConfig {
-	if cnf.DynamicConfig == nil {
-		return readCircuitBreakerConfigFromLocalConfig(client)
-	}
-
-	return readCircuitBreakerConfigFromDynConfig(client, cnf)
+	return clientPropertiesOf(cnf).circuitBreakerConfig(client)
 }
 
-func readCircuitBreakerConfigFromLocalConfig(client string) CircuitBreakerClientConfig {
-	v := viper.New()
-	v.SetConfigType(FileType)
-	v.AddConfigPath(getConfigDirectory())
-	v.SetConfigName(LocalConfigName)
-
-	if err := v.ReadInConfig(); err != nil {
-		log.Errorf(ReadConfigErrorLog, client, err.Error())
-	}
-
-	failurePercentageThresholdConfig := v.GetString(join(client, CircuitBreakerFailurePercentageThresholdSuffix))
+func parseCircuitBreakerConfig(client string, get func(suffix string) string) CircuitBreakerClientConfig {
+	failurePercentageThresholdConfig := get(CircuitBreakerFailurePercentageThresholdSuffix)
 	failurePercentageThresholdConfigInt, err := strconv.ParseUint(failurePercentageThresholdConfig, Base10, BitSize32)
 	if err != nil {
 		log.Warnf(StringToIntParsingError, "failurePercentageThresholdConfig", client, err)
 
 		failurePercentageThresholdConfigInt = DefaultCircuitBreakerPercentageThreshold
+		defaultSources.recordDefault(join(client, CircuitBreakerFailurePercentageThresholdSuffix), failurePercentageThresholdConfigInt)
 	}
 
-	failureMinExecutionThresholdConfig := v.GetString(join(client, CircuitBreakerMinExecutionThresholdSuffix))
+	failureMinExecutionThresholdConfig := get(CircuitBreakerMinExecutionThresholdSuffix)
 	failureMinExecutionThresholdConfigInt, err := strconv.ParseUint(failureMinExecutionThresholdConfig, Base10, BitSize32)
 	if err != nil {
 		log.Warnf(StringToIntParsingError, "failureMinExecutionThresholdConfig", client, err)
 
 		failureMinExecutionThresholdConfigInt = DefaultCircuitMinExecutionThreshold
+		defaultSources.recordDefault(join(client, CircuitBreakerMinExecutionThresholdSuffix), failureMinExecutionThresholdConfigInt)
 	}
 
-	failurePeriodThresholdConfig := v.GetString(join(client, CircuitBreakerFailurePeriodThresholdSuffix))
+	failurePeriodThresholdConfig := get(CircuitBreakerFailurePeriodThresholdSuffix)
 	failurePeriodThresholdConfigInDuration, err := time.ParseDuration(join(failurePeriodThresholdConfig, TimeInSeconds))
 
 	if failurePeriodThresholdConfigInDuration == 0 || err != nil {
 		log.Warnf(StringToIntParsingError, "failurePeriodThresholdConfig", client, err)
 
 		failurePeriodThresholdConfigInDuration = DefaultCircuitBreakerFailurePeriodThresholdInSeconds * time.Second
+		defaultSources.recordDefault(join(client, CircuitBreakerFailurePeriodThresholdSuffix), failurePeriodThresholdConfigInDuration)
 	}
 
-	successThresholdConfig := v.GetString(join(client, CircuitBreakerSuccessThresholdSuffix))
+	successThresholdConfig := get(CircuitBreakerSuccessThresholdSuffix)
 	successThresholdConfigInt, err := strconv.ParseUint(successThresholdConfig, Base10, BitSize32)
 	if err != nil {
 		log.Warnf(StringToIntParsingError, "successThresholdConfig", client, err)
 
 		successThresholdConfigInt = DefaultCircuitBreakerSuccessThreshold
+		defaultSources.recordDefault(join(client, CircuitBreakerSuccessThresholdSuffix), successThresholdConfigInt)
 	}
 
-	delayConfig := v.GetString(join(client, CircuitBreakerDelaySuffix))
+	delayConfig := get(CircuitBreakerDelaySuffix)
 	delayConfigInDuration, err := time.ParseDuration(join(delayConfig, TimeInMs))
 
 	if delayConfigInDuration == 0 || err != nil {
 		delayConfigInDuration = DefaultCircuitBreakerDelayMs * time.Millisecond
+		defaultSources.recordDefault(join(client, CircuitBreakerDelaySuffix), delayConfigInDuration)
 	}
 
 	return CircuitBreakerClientConfig{
@@ -372,148 +488,321 @@ func readCircuitBreakerConfigFromLocalConfig(client string) CircuitBreakerClient
 	}
 }
 
-func readCircuitBreakerConfigFromDynConfig(client string, cnf *Config) CircuitBreakerClientConfig {
-	// Reading from aws App Config
-	failurePercentageThresholdConfig, err := cnf.DynamicConfig.Get(client + CircuitBreakerFailurePercentageThresholdSuffix)
-	if err != nil {
-		log.Warnf(DynConfigParsingError, "failurePercentageThresholdConfig", client, err)
+// GetCircuitBreakerMethodConfigs returns the circuit breaker config for a method of the client. The client configs are
+// overridden by the `<client>.<rpc>.cb.*` configs, where rpc is the method name without its service, e.g.
+// `resource.GetAncestorsOfAFacility.cb.delay` for `/resource.Resource/GetAncestorsOfAFacility`
+func GetCircuitBreakerMethodConfigs(client, method string, cnf *Config) CircuitBreakerClientConfig {
+	cbConfig := GetCircuitBreakerClientConfigs(client, cnf)
+	methodKey := join(client, ".", rpcName(method))
+
+	return overrideCircuitBreakerConfig(methodKey, cbConfig, clientConfigGetter(methodKey, cnf))
+}
+
+// overrideCircuitBreakerConfig overrides the configs which are set for the key, invalid values are ignored
+func overrideCircuitBreakerConfig(key string, cbConfig CircuitBreakerClientConfig, get func(suffix string) string) CircuitBreakerClientConfig {
+	overrideUint := func(name, suffix string, val *uint) {
+		if valConfig := get(suffix); valConfig != "" {
+			valInt, err := strconv.ParseUint(valConfig, Base10, BitSize32)
+			if err != nil {
+				log.Warnf(StringToIntParsingError, name, key, err)
+				return
+			}
+
+			*val = uint(valInt)
+		}
 	}
 
-	failurePercentageThresholdConfigInt, err := strconv.ParseUint(failurePercentageThresholdConfig, 10, 32)
-	if err != nil {
-		log.Warnf(StringToIntParsingError, "failurePercentageThresholdConfig", client, err)
+	overrideDuration := func(name, suffix, unit string, val *time.Duration) {
+		if valConfig := get(suffix); valConfig != "" {
+			valDuration, err := time.ParseDuration(join(valConfig, unit))
+			if valDuration == 0 || err != nil {
+				log.Warnf(StringToIntParsingError, name, key, err)
+				return
+			}
 
-		failurePercentageThresholdConfigInt = DefaultCircuitBreakerPercentageThreshold
+			*val = valDuration
+		}
 	}
 
-	failureMinExecutionThresholdConfig, err := cnf.DynamicConfig.Get(client + CircuitBreakerMinExecutionThresholdSuffix)
+	overrideUint("failurePercentageThresholdConfig", CircuitBreakerFailurePercentageThresholdSuffix, &cbConfig.FailurePercentageThresholdWithinTimePeriod)
+	overrideUint("failureMinExecutionThresholdConfig", CircuitBreakerMinExecutionThresholdSuffix, &cbConfig.FailureMinExecutionThresholdWithinTimePeriod)
+	overrideDuration("failurePeriodThresholdConfig", CircuitBreakerFailurePeriodThresholdSuffix, TimeInSeconds, &cbConfig.FailurePeriodThreshold)
+	overrideUint("successThresholdConfig", CircuitBreakerSuccessThresholdSuffix, &cbConfig.SuccessThreshold)
+	overrideDuration("delayConfig", CircuitBreakerDelaySuffix, TimeInMs, &cbConfig.Delay)
+
+	return cbConfig
+}
+
+// rpcName returns the rpc name of a full grpc method name, e.g. GetUser for /user.User/GetUser
+func rpcName(method string) string {
+	return method[strings.LastIndex(method, "/")+1:]
+}
+
+func GetRetryClientConfigs(client string, cnf *Config) RetryClientConfig {
+	return clientPropertiesOf(cnf).retryConfig(client)
+}
+
+func parseRetryConfig(client string, get func(suffix string) string) RetryClientConfig {
+	maxRetriesConfig := get(RetryMaxRetriesSuffix)
+	maxRetriesConfigInt, err := strconv.ParseInt(maxRetriesConfig, Base10, BitSize32)
 	if err != nil {
-		log.Warnf(DynConfigParsingError, "failureMinExecutionThresholdConfig", client, err)
+		log.Warnf("error parsing maxRetriesConfig for client: %v ,Error: %v, using defaults", client, err.Error())
+
+		maxRetriesConfigInt = DefaultRetryMaxRetries
+		defaultSources.recordDefault(join(client, RetryMaxRetriesSuffix), maxRetriesConfigInt)
 	}
 
-	failureMinExecutionThresholdConfigInt, err := strconv.ParseUint(failureMinExecutionThresholdConfig, 10, 32)
-	if err != nil {
-		log.Warnf(StringToIntParsingError, "failureMinExecutionThresholdConfig", client, err)
+	delayConfig := get(RetryDelaySuffix)
+	delayConfigInDuration, err := time.ParseDuration(join(delayConfig, TimeInMs))
 
-		failureMinExecutionThresholdConfigInt = DefaultCircuitMinExecutionThreshold
+	if delayConfigInDuration == 0 || err != nil {
+		log.Warnf("error parsing retry delayConfig for client: %v ,Error: %v, using defaults", client, err)
+
+		delayConfigInDuration = DefaultRetryDelayMs * time.Millisecond
+		defaultSources.recordDefault(join(client, RetryDelaySuffix), delayConfigInDuration)
 	}
 
-	failurePeriodThresholdConfig, err := cnf.DynamicConfig.Get(client + CircuitBreakerFailurePeriodThresholdSuffix)
-	if err != nil {
-		log.Warnf(DynConfigParsingError, "failurePeriodThresholdConfig", client, err)
+	return RetryClientConfig{
+		MaxRetries:       int(maxRetriesConfigInt),
+		Delay:            delayConfigInDuration,
+		MaxDelay:         parseRetryMaxDelay(client, get(RetryMaxDelaySuffix), delayConfigInDuration),
+		JitterFactor:     parseRetryJitterFactor(client, get(RetryJitterFactorSuffix)),
+		BudgetMaxTokens:  parseFloatConfig(client, "budgetMaxTokensConfig", get(RetryBudgetMaxTokensSuffix), DefaultRetryBudgetMaxTokens),
+		BudgetTokenRatio: parseFloatConfig(client, "budgetTokenRatioConfig", get(RetryBudgetTokenRatioSuffix), DefaultRetryBudgetTokenRatio),
+		Methods:          parseMethodsConfig(get(RetryMethodsSuffix)),
 	}
+}
 
-	failurePeriodThresholdConfigInDuration, err := time.ParseDuration(join(failurePeriodThresholdConfig, TimeInSeconds))
-	if failurePeriodThresholdConfigInDuration == 0 || err != nil {
-		log.Warnf(StringToIntParsingError, "failurePeriodThresholdConfig", client, err)
+func GetHedgeClientConfigs(client string, cnf *Config) HedgeClientConfig {
+	return parseHedgeConfig(client, clientConfigGetter(client, cnf))
+}
 
-		failurePeriodThresholdConfigInDuration = DefaultCircuitBreakerFailurePeriodThresholdInSeconds * time.Second
+func GetPoolClientConfigs(client string, cnf *Config) PoolClientConfig {
+	get := clientConfigGetter(client, cnf)
+
+	size, err := strconv.Atoi(get(PoolSizeSuffix))
+	if size < 1 || err != nil {
+		size = DefaultPoolSize
 	}
 
-	successThresholdConfig, err := cnf.DynamicConfig.Get(client + CircuitBreakerSuccessThresholdSuffix)
-	if err != nil {
-		log.Warnf(DynConfigParsingError, "successThresholdConfig", client, err)
+	idleTimeout, err := time.ParseDuration(join(get(PoolIdleTimeoutSuffix), TimeInMs))
+	if idleTimeout < 0 || err != nil {
+		idleTimeout = 0
 	}
 
-	successThresholdConfigInt, err := strconv.ParseUint(successThresholdConfig, 10, 32)
-	if err != nil {
-		log.Warnf(StringToIntParsingError, "successThresholdConfig", client, err)
+	maxLifetime, err := time.ParseDuration(join(get(PoolMaxLifetimeSuffix), TimeInMs))
+	if maxLifetime < 0 || err != nil {
+		maxLifetime = 0
+	}
 
-		successThresholdConfigInt = DefaultCircuitBreakerSuccessThreshold
+	return PoolClientConfig{Size: size, IdleTimeout: idleTimeout, MaxLifetime: maxLifetime}
+}
+
+func GetBulkheadClientConfigs(client string, cnf *Config) BulkheadClientConfig {
+	get := clientConfigGetter(client, cnf)
+
+	maxConcurrency, err := strconv.ParseUint(get(BulkheadMaxConcurrencySuffix), Base10, BitSize32)
+	if maxConcurrency == 0 || err != nil {
+		return BulkheadClientConfig{}
 	}
 
-	delayConfig, err := cnf.DynamicConfig.Get(client + CircuitBreakerDelaySuffix)
-	if err != nil {
-		log.Warnf(DynConfigParsingError, "successThresholdConfig", client, err)
+	maxWait, err := time.ParseDuration(join(get(BulkheadMaxWaitSuffix), TimeInMs))
+	if maxWait < 0 || err != nil {
+		maxWait = DefaultBulkheadMaxWaitMs * time.Millisecond
 	}
 
-	delayConfigInDuration, err := time.ParseDuration(join(delayConfig, TimeInMs))
-	if delayConfigInDuration == 0 || err != nil {
-		log.Warnf(StringToIntParsingError, "delayConfig", client, err)
+	return BulkheadClientConfig{MaxConcurrency: uint(maxConcurrency), MaxWait: maxWait}
+}
 
-		delayConfigInDuration = DefaultCircuitBreakerDelayMs * time.Millisecond
+func GetRateLimitClientConfigs(client string, cnf *Config) RateLimitClientConfig {
+	get := clientConfigGetter(client, cnf)
+
+	rps, err := strconv.ParseFloat(get(RateLimitRPSSuffix), BitSize64)
+	if rps <= 0 || err != nil {
+		return RateLimitClientConfig{}
 	}
 
-	return CircuitBreakerClientConfig{
-		FailurePercentageThresholdWithinTimePeriod:   uint(failurePercentageThresholdConfigInt),
-		FailureMinExecutionThresholdWithinTimePeriod: uint(failureMinExecutionThresholdConfigInt),
-		FailurePeriodThreshold:                       failurePeriodThresholdConfigInDuration,
-		SuccessThreshold:                             uint(successThresholdConfigInt),
-		Delay:                                        delayConfigInDuration,
+	// the burst defaults to a second worth of calls, and at least one call
+	burst, err := strconv.Atoi(get(RateLimitBurstSuffix))
+	if burst <= 0 || err != nil {
+		burst = max(1, int(math.Ceil(rps)))
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(get(RateLimitModeSuffix)))
+	if mode != RateLimitModeReject {
+		mode = RateLimitModeWait
 	}
+
+	return RateLimitClientConfig{RPS: rps, Burst: burst, Mode: mode}
 }
 
-func GetRetryClientConfigs(client string, cnf *Config) RetryClientConfig {
-	if cnf.DynamicConfig == nil {
-		return readRetryConfigFromLocalConfig(client)
+func GetCassetteClientConfigs(client string, cnf *Config) CassetteClientConfig {
+	get := clientConfigGetter(client, cnf)
+
+	dir := strings.TrimSpace(get(CassetteDirSuffix))
+	if dir == "" {
+		dir = DefaultCassetteDir
 	}
 
-	return readRetryFromDynConfig(client, cnf)
+	return CassetteClientConfig{Mode: strings.ToLower(strings.TrimSpace(get(CassetteModeSuffix))), Dir: dir}
 }
 
-func readRetryConfigFromLocalConfig(client string) RetryClientConfig {
-	v := viper.New()
-	v.SetConfigType(FileType)
-	v.AddConfigPath(getConfigDirectory())
-	v.SetConfigName(LocalConfigName)
+func GetFallbackClientConfigs(client string, cnf *Config) FallbackClientConfig {
+	get := clientConfigGetter(client, cnf)
 
-	if err := v.ReadInConfig(); err != nil {
-		log.Errorf(ReadConfigErrorLog, client, err.Error())
+	methods := parseMethodsConfig(get(FallbackMethodsSuffix))
+	if len(methods) == 0 {
+		return FallbackClientConfig{}
 	}
 
-	maxRetriesConfig := v.GetString(join(client, RetryMaxRetriesSuffix))
-	maxRetriesConfigInt, err := strconv.ParseInt(maxRetriesConfig, Base10, BitSize32)
+	maxEntries, err := strconv.Atoi(get(FallbackMaxEntriesSuffix))
+	if maxEntries <= 0 || err != nil {
+		maxEntries = DefaultFallbackMaxEntries
+	}
+
+	maxAge, err := time.ParseDuration(join(get(FallbackMaxAgeSuffix), TimeInMs))
+	if maxAge < 0 || err != nil {
+		maxAge = 0
+	}
+
+	return FallbackClientConfig{Methods: methods, MaxEntries: maxEntries, MaxAge: maxAge}
+}
+
+func GetTLSClientConfigs(client string, cnf *Config) TLSClientConfig {
+	get := clientConfigGetter(client, cnf)
+
+	return TLSClientConfig{
+		Mode:       strings.ToLower(strings.TrimSpace(get(TLSModeSuffix))),
+		CAFile:     get(TLSCAFileSuffix),
+		CertFile:   get(TLSCertFileSuffix),
+		KeyFile:    get(TLSKeyFileSuffix),
+		ServerName: get(TLSServerNameSuffix),
+	}
+}
+
+func GetDialClientConfigs(client string, cnf *Config) DialClientConfig {
+	get := clientConfigGetter(client, cnf)
+
+	keepaliveTime, err := time.ParseDuration(join(get(KeepaliveTimeSuffix), TimeInMs))
+	if keepaliveTime < 0 || err != nil {
+		keepaliveTime = 0
+	}
+
+	keepaliveTimeout, err := time.ParseDuration(join(get(KeepaliveTimeoutSuffix), TimeInMs))
+	if keepaliveTimeout <= 0 || err != nil {
+		keepaliveTimeout = DefaultKeepaliveTimeoutMs * time.Millisecond
+	}
+
+	permitWithoutStream, err := strconv.ParseBool(get(KeepalivePermitWithoutStreamSuffix))
 	if err != nil {
-		log.Warnf("error parsing maxRetriesConfig for client: %v ,Error: %v, using defaults", client, err.Error())
+		permitWithoutStream = false
+	}
 
-		maxRetriesConfigInt = DefaultRetryMaxRetries
+	maxRecvMsgSize, err := strconv.Atoi(get(MaxRecvMsgSizeSuffix))
+	if maxRecvMsgSize < 0 || err != nil {
+		maxRecvMsgSize = 0
 	}
 
-	delayConfig := v.GetString(join(client, RetryDelaySuffix))
-	delayConfigInDuration, err := time.ParseDuration(join(delayConfig, TimeInMs))
+	maxSendMsgSize, err := strconv.Atoi(get(MaxSendMsgSizeSuffix))
+	if maxSendMsgSize < 0 || err != nil {
+		maxSendMsgSize = 0
+	}
 
-	if delayConfigInDuration == 0 || err != nil {
-		log.Warnf("error parsing retry delayConfig for client: %v ,Error: %v, using defaults", client, err)
+	return DialClientConfig{
+		Resolver:                     strings.ToLower(strings.TrimSpace(get(ResolverSuffix))),
+		LBPolicy:                     strings.ToLower(strings.TrimSpace(get(LBPolicySuffix))),
+		KeepaliveTime:                keepaliveTime,
+		KeepaliveTimeout:             keepaliveTimeout,
+		KeepalivePermitWithoutStream: permitWithoutStream,
+		MaxRecvMsgSize:               maxRecvMsgSize,
+		MaxSendMsgSize:               maxSendMsgSize,
+		Compression:                  strings.ToLower(strings.TrimSpace(get(CompressionSuffix))),
+		ServiceConfig:                strings.TrimSpace(get(ServiceConfigSuffix)),
+	}
+}
 
-		delayConfigInDuration = DefaultRetryDelayMs * time.Millisecond
+// clientConfigGetter returns a getter of the optional `<key><suffix>` configs of a client, resolved from the layers of
+// the config, see LayerDefault
+func clientConfigGetter(key string, cnf *Config) func(suffix string) string {
+	props := localClientProperties()
+
+	return func(suffix string) string {
+		return resolve(cnf, props, join(key, suffix))
+	}
+}
+
+func parseHedgeConfig(client string, get func(suffix string) string) HedgeClientConfig {
+	delay, err := time.ParseDuration(join(get(HedgeDelaySuffix), TimeInMs))
+	if delay <= 0 || err != nil {
+		delay = DefaultHedgeDelayMs * time.Millisecond
 	}
 
-	return RetryClientConfig{
-		MaxRetries: int(maxRetriesConfigInt),
-		Delay:      delayConfigInDuration,
+	budgetRatio := parseFloatConfig(client, "hedgeBudgetRatioConfig", get(HedgeBudgetRatioSuffix), DefaultHedgeBudgetRatio)
+	if budgetRatio < 0 || budgetRatio > 1 {
+		log.Warnf("hedge budget_ratio %v is not within [0, 1] for client: %v, using defaults", budgetRatio, client)
+
+		budgetRatio = DefaultHedgeBudgetRatio
+	}
+
+	return HedgeClientConfig{
+		Delay:       delay,
+		BudgetRatio: budgetRatio,
+		Methods:     parseMethodsConfig(get(HedgeMethodsSuffix)),
 	}
 }
 
-func readRetryFromDynConfig(client string, cnf *Config) RetryClientConfig {
-	// Reading from aws App Config
-	maxRetriesConfig, err := cnf.DynamicConfig.Get(client + RetryMaxRetriesSuffix)
-	if err != nil {
-		log.Warnf("error fetching failureThresholdConfig aws config for client: %v ,Error: %v", client, err.Error())
+func parseRetryMaxDelay(client, maxDelayConfig string, delay time.Duration) time.Duration {
+	maxDelay, err := time.ParseDuration(join(maxDelayConfig, TimeInMs))
+	if maxDelay == 0 || err != nil {
+		maxDelay = DefaultRetryMaxDelayMs * time.Millisecond
 	}
 
-	maxRetriesConfigInt, err := strconv.ParseInt(maxRetriesConfig, Base10, BitSize32)
-	if err != nil {
-		log.Warnf("error parsing maxRetriesConfig for client: %v ,Error: %v, using defaults", client, err.Error())
+	if maxDelay < delay {
+		log.Warnf("retry max_delay %v is lower than delay %v for client: %v, using delay", maxDelay, delay, client)
 
-		maxRetriesConfigInt = DefaultRetryMaxRetries
+		return delay
 	}
 
-	delayConfig, err := cnf.DynamicConfig.Get(client + RetryDelaySuffix)
-	if err != nil {
-		log.Warnf("error fetching retry delayConfig aws config for client: %v ,Error: %v", client, err.Error())
+	return maxDelay
+}
+
+func parseRetryJitterFactor(client, jitterConfig string) float32 {
+	jitter := parseFloatConfig(client, "jitterFactorConfig", jitterConfig, DefaultRetryJitterFactor)
+	if jitter < 0 || jitter > 1 {
+		log.Warnf("retry jitter_factor %v is not within [0, 1] for client: %v, using defaults", jitter, client)
+
+		return DefaultRetryJitterFactor
 	}
 
-	delayConfigInDuration, err := time.ParseDuration(join(delayConfig, TimeInMs))
-	if delayConfigInDuration == 0 || err != nil {
-		log.Warnf("error parsing retry delayConfig for client: %v ,Error: %v, using defaults", client, err)
+	return float32(jitter)
+}
 
-		delayConfigInDuration = DefaultRetryDelayMs * time.Millisecond
+func parseFloatConfig(client, name, valConfig string, defaultVal float64) float64 {
+	if valConfig == "" {
+		return defaultVal
 	}
 
-	return RetryClientConfig{
-		MaxRetries: int(maxRetriesConfigInt),
-		Delay:      delayConfigInDuration,
+	val, err := strconv.ParseFloat(valConfig, BitSize64)
+	if err != nil {
+		log.Warnf(StringToIntParsingError, name, client, err)
+
+		return defaultVal
+	}
+
+	return val
+}
+
+// parseMethodsConfig parses a comma separated allowlist of methods, e.g. the methods which may be retried or hedged
+func parseMethodsConfig(methodsConfig string) []string {
+	var methods []string
+
+	for _, method := range strings.Split(methodsConfig, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			methods = append(methods, method)
+		}
 	}
+
+	return methods
 }
 
 func join(strs ...string) string {
@@ -129,8 +129,12 @@ func TestGetRetryClientConfigs(t *testing.T) {
 				cnf:    &Config{DynamicConfig: nil},
 			},
 			want: RetryClientConfig{
-				MaxRetries: DefaultRetryMaxRetries,
-				Delay:      DefaultRetryDelayMs * time.Millisecond,
+				MaxRetries:       DefaultRetryMaxRetries,
+				Delay:            DefaultRetryDelayMs * time.Millisecond,
+				MaxDelay:         DefaultRetryMaxDelayMs * time.Millisecond,
+				JitterFactor:     DefaultRetryJitterFactor,
+				BudgetMaxTokens:  DefaultRetryBudgetMaxTokens,
+				BudgetTokenRatio: DefaultRetryBudgetTokenRatio,
 			},
 			mock: []*gomock.Call{
 				// No mocks for DynamicConfig since it's nil
@@ -143,12 +147,22 @@ func TestGetRetryClientConfigs(t *testing.T) {
 				cnf:    &Config{DynamicConfig: dc},
 			},
 			want: RetryClientConfig{
-				MaxRetries: 3,
-				Delay:      1 * time.Second,
+				MaxRetries:       3,
+				Delay:            1 * time.Second,
+				MaxDelay:         4 * time.Second,
+				JitterFactor:     0.5,
+				BudgetMaxTokens:  20,
+				BudgetTokenRatio: 0.2,
+				Methods:          []string{"/user.User/GetUser", "/page.Page/GetPageFromCache"},
 			},
 			mock: []*gomock.Call{
 				dc.EXPECT().Get("test-client"+RetryMaxRetriesSuffix).Return("3", nil),
 				dc.EXPECT().Get("test-client"+RetryDelaySuffix).Return("1000", nil),
+				dc.EXPECT().Get("test-client"+RetryMaxDelaySuffix).Return("4000", nil),
+				dc.EXPECT().Get("test-client"+RetryJitterFactorSuffix).Return("0.5", nil),
+				dc.EXPECT().Get("test-client"+RetryBudgetMaxTokensSuffix).Return("20", nil),
+				dc.EXPECT().Get("test-client"+RetryBudgetTokenRatioSuffix).Return("0.2", nil),
+				dc.EXPECT().Get("test-client"+RetryMethodsSuffix).Return("/user.User/GetUser, /page.Page/GetPageFromCache", nil),
 			},
 		},
 		{
@@ -158,12 +172,21 @@ func TestGetRetryClientConfigs(t *testing.T) {
 				cnf:    &Config{DynamicConfig: dc},
 			},
 			want: RetryClientConfig{
-				MaxRetries: DefaultRetryMaxRetries,
-				Delay:      DefaultRetryDelayMs * time.Millisecond,
+				MaxRetries:       DefaultRetryMaxRetries,
+				Delay:            DefaultRetryDelayMs * time.Millisecond,
+				MaxDelay:         DefaultRetryMaxDelayMs * time.Millisecond,
+				JitterFactor:     DefaultRetryJitterFactor,
+				BudgetMaxTokens:  DefaultRetryBudgetMaxTokens,
+				BudgetTokenRatio: DefaultRetryBudgetTokenRatio,
 			},
 			mock: []*gomock.Call{
 				dc.EXPECT().Get("test-client"+RetryMaxRetriesSuffix).Return("invalid", nil),
 				dc.EXPECT().Get("test-client"+RetryDelaySuffix).Return("invalid", nil),
+				dc.EXPECT().Get("test-client"+RetryMaxDelaySuffix).Return("invalid", nil),
+				dc.EXPECT().Get("test-client"+RetryJitterFactorSuffix).Return("2", nil),
+				dc.EXPECT().Get("test-client"+RetryBudgetMaxTokensSuffix).Return("invalid", nil),
+				dc.EXPECT().Get("test-client"+RetryBudgetTokenRatioSuffix).Return("invalid", nil),
+				dc.EXPECT().Get("test-client"+RetryMethodsSuffix).Return("", nil),
 			},
 		},
 	}
@@ -186,3 +209,469 @@ func TestGetRetryClientConfigs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCircuitBreakerMethodConfigs(t *testing.T) {
+	client := "test-client"
+	method := "/resource.Resource/GetAncestorsOfAFacility"
+	methodKey := "test-client.GetAncestorsOfAFacility"
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      CircuitBreakerClientConfig
+	}{
+		{
+			name:      "Client config used when method has no overrides",
+			overrides: map[string]string{},
+			want: CircuitBreakerClientConfig{
+				FailurePercentageThresholdWithinTimePeriod:   50,
+				FailureMinExecutionThresholdWithinTimePeriod: 10,
+				FailurePeriodThreshold:                       60 * time.Second,
+				SuccessThreshold:                             10,
+				Delay:                                        500 * time.Millisecond,
+			},
+		},
+		{
+			name: "Method overrides client config",
+			overrides: map[string]string{
+				CircuitBreakerFailurePercentageThresholdSuffix: "20",
+				CircuitBreakerDelaySuffix:                      "1000",
+				CircuitBreakerSuccessThresholdSuffix:           "invalid",
+			},
+			want: CircuitBreakerClientConfig{
+				FailurePercentageThresholdWithinTimePeriod:   20,
+				FailureMinExecutionThresholdWithinTimePeriod: 10,
+				FailurePeriodThreshold:                       60 * time.Second,
+				SuccessThreshold:                             10,
+				Delay:                                        1 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			dc.EXPECT().Get(client+CircuitBreakerFailurePercentageThresholdSuffix).Return("50", nil)
+			dc.EXPECT().Get(client+CircuitBreakerMinExecutionThresholdSuffix).Return("10", nil)
+			dc.EXPECT().Get(client+CircuitBreakerFailurePeriodThresholdSuffix).Return("60", nil)
+			dc.EXPECT().Get(client+CircuitBreakerSuccessThresholdSuffix).Return("10", nil)
+			dc.EXPECT().Get(client+CircuitBreakerDelaySuffix).Return("500", nil)
+
+			for _, suffix := range []string{CircuitBreakerFailurePercentageThresholdSuffix, CircuitBreakerMinExecutionThresholdSuffix,
+				CircuitBreakerFailurePeriodThresholdSuffix, CircuitBreakerSuccessThresholdSuffix, CircuitBreakerDelaySuffix} {
+				dc.EXPECT().Get(methodKey+suffix).Return(tt.overrides[suffix], nil)
+			}
+
+			got := GetCircuitBreakerMethodConfigs(client, method, &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCircuitBreakerMethodConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetHedgeClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   HedgeClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				HedgeDelaySuffix:       "80",
+				HedgeBudgetRatioSuffix: "0.05",
+				HedgeMethodsSuffix:     "/user.User/GetUser",
+			},
+			want: HedgeClientConfig{
+				Delay:       80 * time.Millisecond,
+				BudgetRatio: 0.05,
+				Methods:     []string{"/user.User/GetUser"},
+			},
+		},
+		{
+			name: "Dynamic config with parsing errors",
+			values: map[string]string{
+				HedgeDelaySuffix:       "invalid",
+				HedgeBudgetRatioSuffix: "2",
+			},
+			want: HedgeClientConfig{
+				Delay:       DefaultHedgeDelayMs * time.Millisecond,
+				BudgetRatio: DefaultHedgeBudgetRatio,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{HedgeDelaySuffix, HedgeBudgetRatioSuffix, HedgeMethodsSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil)
+			}
+
+			got := GetHedgeClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetHedgeClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDialClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   DialClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				ResolverSuffix:                     "DNS",
+				LBPolicySuffix:                     " round_robin ",
+				KeepaliveTimeSuffix:                "30000",
+				KeepaliveTimeoutSuffix:             "5000",
+				KeepalivePermitWithoutStreamSuffix: "true",
+				MaxRecvMsgSizeSuffix:               "8388608",
+				MaxSendMsgSizeSuffix:               "1048576",
+				CompressionSuffix:                  "gzip",
+				ServiceConfigSuffix:                `{"loadBalancingConfig": [{"round_robin": {}}]}`,
+			},
+			want: DialClientConfig{
+				Resolver:                     ResolverDNS,
+				LBPolicy:                     LBPolicyRoundRobin,
+				KeepaliveTime:                30 * time.Second,
+				KeepaliveTimeout:             5 * time.Second,
+				KeepalivePermitWithoutStream: true,
+				MaxRecvMsgSize:               8388608,
+				MaxSendMsgSize:               1048576,
+				Compression:                  "gzip",
+				ServiceConfig:                `{"loadBalancingConfig": [{"round_robin": {}}]}`,
+			},
+		},
+		{
+			name: "Dynamic config with parsing errors",
+			values: map[string]string{
+				KeepaliveTimeSuffix:                "invalid",
+				KeepaliveTimeoutSuffix:             "-1",
+				KeepalivePermitWithoutStreamSuffix: "invalid",
+				MaxRecvMsgSizeSuffix:               "-1",
+				MaxSendMsgSizeSuffix:               "invalid",
+			},
+			want: DialClientConfig{KeepaliveTimeout: DefaultKeepaliveTimeoutMs * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{ResolverSuffix, LBPolicySuffix, KeepaliveTimeSuffix, KeepaliveTimeoutSuffix,
+				KeepalivePermitWithoutStreamSuffix, MaxRecvMsgSizeSuffix, MaxSendMsgSizeSuffix, CompressionSuffix, ServiceConfigSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil)
+			}
+
+			got := GetDialClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDialClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBulkheadClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   BulkheadClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				BulkheadMaxConcurrencySuffix: "20",
+				BulkheadMaxWaitSuffix:        "0",
+			},
+			want: BulkheadClientConfig{MaxConcurrency: 20},
+		},
+		{
+			name: "Dynamic config with parsing errors",
+			values: map[string]string{
+				BulkheadMaxConcurrencySuffix: "20",
+				BulkheadMaxWaitSuffix:        "invalid",
+			},
+			want: BulkheadClientConfig{
+				MaxConcurrency: 20,
+				MaxWait:        DefaultBulkheadMaxWaitMs * time.Millisecond,
+			},
+		},
+		{
+			name: "Bulkhead disabled when max concurrency is not set",
+			values: map[string]string{
+				BulkheadMaxConcurrencySuffix: "0",
+			},
+			want: BulkheadClientConfig{},
+		},
+		{
+			name: "Bulkhead disabled when max concurrency is invalid",
+			values: map[string]string{
+				BulkheadMaxConcurrencySuffix: "-1",
+			},
+			want: BulkheadClientConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{BulkheadMaxConcurrencySuffix, BulkheadMaxWaitSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil).MaxTimes(1)
+			}
+
+			got := GetBulkheadClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBulkheadClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRateLimitClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   RateLimitClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				RateLimitRPSSuffix:   "50",
+				RateLimitBurstSuffix: "10",
+				RateLimitModeSuffix:  "Reject",
+			},
+			want: RateLimitClientConfig{RPS: 50, Burst: 10, Mode: RateLimitModeReject},
+		},
+		{
+			name: "Dynamic config with default burst and mode",
+			values: map[string]string{
+				RateLimitRPSSuffix:   "2.5",
+				RateLimitBurstSuffix: "invalid",
+			},
+			want: RateLimitClientConfig{RPS: 2.5, Burst: 3, Mode: RateLimitModeWait},
+		},
+		{
+			name:   "Dynamic config without rate limit",
+			values: map[string]string{},
+			want:   RateLimitClientConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{RateLimitRPSSuffix, RateLimitBurstSuffix, RateLimitModeSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil).AnyTimes()
+			}
+
+			got := GetRateLimitClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRateLimitClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCassetteClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   CassetteClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				CassetteModeSuffix: " Replay ",
+				CassetteDirSuffix:  "testdata/user",
+			},
+			want: CassetteClientConfig{Mode: CassetteModeReplay, Dir: "testdata/user"},
+		},
+		{
+			name:   "Dynamic config without cassette",
+			values: map[string]string{},
+			want:   CassetteClientConfig{Dir: DefaultCassetteDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{CassetteModeSuffix, CassetteDirSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil)
+			}
+
+			got := GetCassetteClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCassetteClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTLSClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   TLSClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				TLSModeSuffix:       " MTLS ",
+				TLSCAFileSuffix:     "/etc/certs/ca.pem",
+				TLSCertFileSuffix:   "/etc/certs/client.pem",
+				TLSKeyFileSuffix:    "/etc/certs/client-key.pem",
+				TLSServerNameSuffix: "test-client.internal",
+			},
+			want: TLSClientConfig{
+				Mode:       TLSModeMTLS,
+				CAFile:     "/etc/certs/ca.pem",
+				CertFile:   "/etc/certs/client.pem",
+				KeyFile:    "/etc/certs/client-key.pem",
+				ServerName: "test-client.internal",
+			},
+		},
+		{
+			name:   "Dynamic config without tls configs",
+			values: map[string]string{},
+			want:   TLSClientConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{TLSModeSuffix, TLSCAFileSuffix, TLSCertFileSuffix, TLSKeyFileSuffix, TLSServerNameSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil)
+			}
+
+			got := GetTLSClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTLSClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPoolClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   PoolClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				PoolSizeSuffix:        "4",
+				PoolIdleTimeoutSuffix: "60000",
+				PoolMaxLifetimeSuffix: "600000",
+			},
+			want: PoolClientConfig{Size: 4, IdleTimeout: time.Minute, MaxLifetime: 10 * time.Minute},
+		},
+		{
+			name: "Dynamic config with parsing errors",
+			values: map[string]string{
+				PoolSizeSuffix:        "0",
+				PoolIdleTimeoutSuffix: "invalid",
+			},
+			want: PoolClientConfig{Size: DefaultPoolSize},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{PoolSizeSuffix, PoolIdleTimeoutSuffix, PoolMaxLifetimeSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil)
+			}
+
+			got := GetPoolClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPoolClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFallbackClientConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   FallbackClientConfig
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				FallbackMethodsSuffix:    "/page.PageService/GetPageFromCache, /user.UserService/GetUser",
+				FallbackMaxEntriesSuffix: "500",
+				FallbackMaxAgeSuffix:     "600000",
+			},
+			want: FallbackClientConfig{
+				Methods:    []string{"/page.PageService/GetPageFromCache", "/user.UserService/GetUser"},
+				MaxEntries: 500,
+				MaxAge:     10 * time.Minute,
+			},
+		},
+		{
+			name: "Dynamic config with default max entries and max age",
+			values: map[string]string{
+				FallbackMethodsSuffix:    "/user.UserService/GetUser",
+				FallbackMaxEntriesSuffix: "invalid",
+				FallbackMaxAgeSuffix:     "-1",
+			},
+			want: FallbackClientConfig{Methods: []string{"/user.UserService/GetUser"}, MaxEntries: DefaultFallbackMaxEntries},
+		},
+		{
+			name:   "Dynamic config without fallback",
+			values: map[string]string{},
+			want:   FallbackClientConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+
+			for _, suffix := range []string{FallbackMethodsSuffix, FallbackMaxEntriesSuffix, FallbackMaxAgeSuffix} {
+				dc.EXPECT().Get("test-client"+suffix).Return(tt.values[suffix], nil).AnyTimes()
+			}
+
+			got := GetFallbackClientConfigs("test-client", &Config{DynamicConfig: dc})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFallbackClientConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// Layers of the config values, from the lowest to the highest precedence: the built-in defaults, the files of
+// /data/conf/<env>, the environment variables and the dynamic config of aws App Config. A value is read from the highest
+// layer which sets it, e.g. `pageServiceConfig.timeout` is read from App Config, else from the
+// BFF_PAGESERVICECONFIG_TIMEOUT environment variable, else from client.properties, else its built-in default of 6000ms
+// applies. Only the environment variables with the EnvPrefix are read, so that the variables set by kubernetes, e.g.
+// the <SVC>_PORT=tcp://... service links, never override a key.
+const (
+	LayerDefault = "default"
+	LayerFile    = "file"
+	LayerEnv     = "env"
+	LayerDynamic = "dynamic"
+
+	EnvPrefix = "BFF"
+
+	RedactedValue = "<redacted>"
+)
+
+// secretKeySuffixes are the endings of the segments of the keys of the values which are never exposed, e.g.
+// server.jwtsecret, akamaiconfig.key or <client>.tls.api_key. Segments are matched as a whole, so that
+// <client>.retry.budget_token_ratio is not redacted.
+//
+//nolint:gochecknoglobals // constant list of suffixes
+var secretKeySuffixes = []string{"secret", "secretiv", "key", "token", "password", "credential", "credentials"}
+
+// Source is the layer which supplied the value of a key, a value of the default layer which is empty means that the
+// built-in default of the key applies
+type Source struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Layer string `json:"layer"`
+}
+
+// Sources records the source of every key resolved by the service, so that it can be explained which layer supplied
+// a value. Keys are case-insensitive like in the config files.
+type Sources struct {
+	mu      sync.RWMutex
+	sources map[string]Source
+}
+
+func NewSources() *Sources {
+	return &Sources{sources: map[string]Source{}}
+}
+
+//nolint:gochecknoglobals // the config values are resolved by package functions all over the service
+var defaultSources = NewSources()
+
+// DefaultSources returns the sources of the values resolved by the package functions
+func DefaultSources() *Sources {
+	return defaultSources
+}
+
+// Explain returns the source of the value of the key as it was last resolved, secret values are redacted
+func Explain(key string) (Source, bool) {
+	return defaultSources.Explain(key)
+}
+
+func (s *Sources) record(key, value, layer string) {
+	id := strings.ToLower(key)
+	source := Source{Key: key, Value: value, Layer: layer}
+
+	s.mu.RLock()
+	current, ok := s.sources[id]
+	s.mu.RUnlock()
+
+	// values are resolved on every call with dynamic config, the map is only written when a source changes. A default
+	// recorded with its value is kept over the empty default recorded when the key was read.
+	if ok && (current == source || (layer == LayerDefault && value == "" && current.Layer == LayerDefault)) {
+		return
+	}
+
+	s.mu.Lock()
+	s.sources[id] = source
+	s.mu.Unlock()
+}
+
+// recordDefault records that the built-in default of the key applies, because the key is not set or its value is invalid
+func (s *Sources) recordDefault(key string, value any) {
+	s.record(key, fmt.Sprint(value), LayerDefault)
+}
+
+// Explain returns the source of the value of the key as it was last resolved, secret values are redacted
+func (s *Sources) Explain(key string) (Source, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	source, ok := s.sources[strings.ToLower(key)]
+
+	return redact(source), ok
+}
+
+// Dump returns the source of every resolved key sorted by key, secret values are redacted
+func (s *Sources) Dump() []Source {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sources := make([]Source, 0, len(s.sources))
+	for _, source := range s.sources {
+		sources = append(sources, redact(source))
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return strings.ToLower(sources[i].Key) < strings.ToLower(sources[j].Key)
+	})
+
+	return sources
+}
+
+func redact(source Source) Source {
+	if source.Value != "" && isSecretKey(source.Key) {
+		source.Value = RedactedValue
+	}
+
+	return source
+}
+
+// isSecretKey reports whether the last word of a segment of the key ends with a secret suffix, e.g. jwtsecret or api_key
+// but not budget_token_ratio or budget_max_tokens
+func isSecretKey(key string) bool {
+	for _, segment := range strings.Split(strings.ToLower(key), ".") {
+		// the words of a segment are separated by _ or -, the last one names what the value is
+		words := strings.FieldsFunc(segment, func(r rune) bool { return r == '_' || r == '-' })
+		if len(words) == 0 {
+			continue
+		}
+
+		last := words[len(words)-1]
+		for _, suffix := range secretKeySuffixes {
+			if strings.HasSuffix(last, suffix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// EnvKey returns the environment variable which sets the key, e.g. BFF_PAGESERVICECONFIG_TIMEOUT for
+// pageServiceConfig.timeout
+func EnvKey(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+//nolint:gochecknoglobals // separators of the keys which are not valid in environment variables
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// recordFileSources records the source of every key of the config file read by v, which viper overrides by the
+// environment variables
+func recordFileSources(v *viper.Viper) {
+	for _, key := range v.AllKeys() {
+		if val, ok := os.LookupEnv(EnvKey(key)); ok && val != "" {
+			defaultSources.record(key, val, LayerEnv)
+			continue
+		}
+
+		defaultSources.record(key, fmt.Sprint(v.Get(key)), LayerFile)
+	}
+}
+
+// resolve returns the value of the key from the highest layer which sets it, and records its source. The dynamic
+// config layer is skipped when the config has none.
+func resolve(cnf *Config, props *clientProperties, key string) string {
+	if cnf != nil && cnf.DynamicConfig != nil {
+		if val, err := cnf.DynamicConfig.Get(key); err == nil && val != "" {
+			defaultSources.record(key, val, LayerDynamic)
+			return val
+		}
+	}
+
+	if val, ok := os.LookupEnv(EnvKey(key)); ok && val != "" {
+		defaultSources.record(key, val, LayerEnv)
+		return val
+	}
+
+	if val := props.get(key); val != "" {
+		defaultSources.record(key, val, LayerFile)
+		return val
+	}
+
+	defaultSources.record(key, "", LayerDefault)
+
+	return ""
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	props := &clientProperties{values: map[string]string{"layer-client.timeout": "100"}}
+
+	tests := []struct {
+		name     string
+		env      string
+		dyn      string
+		dynErr   error
+		noDyn    bool
+		noFile   bool
+		want     string
+		wantFrom string
+	}{
+		{name: "dynamic config over every layer", env: "200", dyn: "300", want: "300", wantFrom: LayerDynamic},
+		{name: "env when dynamic config is not set", env: "200", want: "200", wantFrom: LayerEnv},
+		{name: "env when dynamic config fails", env: "200", dynErr: errors.New("app config unavailable"), want: "200", wantFrom: LayerEnv},
+		{name: "file when env is not set", want: "100", wantFrom: LayerFile},
+		{name: "file without dynamic config", noDyn: true, want: "100", wantFrom: LayerFile},
+		{name: "default when no layer sets the key", noFile: true, want: "", wantFrom: LayerDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cnf := &Config{}
+
+			if !tt.noDyn {
+				dc := NewMockDynamicConfig(ctrl)
+				dc.EXPECT().Get("layer-client.timeout").Return(tt.dyn, tt.dynErr)
+				cnf.DynamicConfig = dc
+			}
+
+			t.Setenv(EnvKey("layer-client.timeout"), tt.env)
+
+			p := props
+			if tt.noFile {
+				p = newClientProperties(nil)
+			}
+
+			assert.Equal(t, tt.want, resolve(cnf, p, "layer-client.timeout"))
+
+			source, ok := Explain("Layer-Client.Timeout")
+			require.True(t, ok, "keys are case-insensitive")
+			assert.Equal(t, Source{Key: "layer-client.timeout", Value: tt.want, Layer: tt.wantFrom}, source)
+		})
+	}
+}
+
+func TestResolveRecordsDefaults(t *testing.T) {
+	cfg := parseClientConfig("defaults-client", newClientProperties(nil).getter("defaults-client"))
+	assert.Equal(t, DefaultClientTimeoutMs*time.Millisecond, cfg.Timeout)
+
+	source, ok := Explain("defaults-client.timeout")
+	require.True(t, ok)
+	assert.Equal(t, Source{Key: "defaults-client.timeout", Value: "6s", Layer: LayerDefault}, source)
+
+	// reading the key again keeps the default recorded with its value
+	resolve(nil, newClientProperties(nil), "defaults-client.timeout")
+
+	source, _ = Explain("defaults-client.timeout")
+	assert.Equal(t, "6s", source.Value)
+}
+
+func TestSources(t *testing.T) {
+	s := NewSources()
+	s.record("server.jwtSecret", "s3cr3t", LayerFile)
+	s.record("server.port", ":8080", LayerFile)
+	s.record("AKAMAICONFIG_KEY", "", LayerDefault)
+	s.record("server.port", ":9090", LayerEnv)
+
+	assert.Equal(t, []Source{
+		{Key: "AKAMAICONFIG_KEY", Value: "", Layer: LayerDefault},
+		{Key: "server.jwtSecret", Value: RedactedValue, Layer: LayerFile},
+		{Key: "server.port", Value: ":9090", Layer: LayerEnv},
+	}, s.Dump())
+
+	source, ok := s.Explain("SERVER.JWTSECRET")
+	assert.True(t, ok)
+	assert.Equal(t, RedactedValue, source.Value)
+
+	_, ok = s.Explain("server.readTimeout")
+	assert.False(t, ok)
+}
+
+func TestIsSecretKey(t *testing.T) {
+	for _, key := range []string{"server.jwtSecret", "akamaiConfig.key", "page-client.tls.api_key", "server.aesSecretIV", "redis.password"} {
+		assert.True(t, isSecretKey(key), key)
+	}
+
+	for _, key := range []string{"page-client.retry.budget_max_tokens", "page-client.retry.budget_token_ratio",
+		"page-client.tls.key_file", "server.jwtSecretLocation", "page-client.timeout"} {
+		assert.False(t, isSecretKey(key), key)
+	}
+}
+
+func TestEnvKey(t *testing.T) {
+	assert.Equal(t, "BFF_PAGESERVICECONFIG_TIMEOUT", EnvKey("pageServiceConfig.timeout"))
+	assert.Equal(t, "BFF_PAGE_CLIENT_CB_DELAY", EnvKey("page-client.cb.delay"))
+}
@@ -0,0 +1,268 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clients "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/clients/constants"
+)
+
+//nolint:gochecknoglobals // the clients created by the commons, validated when the service configures them
+var builtInClients = []string{
+	clients.AuthenticationServiceClient,
+	clients.PageServiceClient,
+	clients.ResourceServiceClient,
+	clients.UserServiceClient,
+	clients.AuthorizationPdpServiceClient,
+	clients.CalServiceClient,
+}
+
+const (
+	MaxPort                     = 65535
+	MaxCircuitBreakerPercentage = 100
+	MaxRetryJitterFactor        = 1
+)
+
+// Validate checks the config of the service at startup and reports every problem at once: the required server fields,
+// and the profile of every client, see ValidateClients. A client profile must have a valid endpoint, positive timeouts,
+// circuit breaker thresholds within their range and valid retry counts. Values which are not set are not reported,
+// their defaults are valid. The client profiles of a service which reads them from App Config are not validated until
+// its dynamic config is initialised.
+func (c *Config) Validate(clients ...string) error {
+	problems := c.validateServer()
+
+	if c.DynamicConfig != nil || c.AppConfig.AppID == "" {
+		problems = append(problems, c.clientProblems(clients)...)
+	}
+
+	return errors.Join(problems...)
+}
+
+// ValidateClients checks the profile of every client and reports every problem at once: the clients passed, the
+// built-in clients with an endpoint, and the clients of the client properties file when there is no dynamic config
+func (c *Config) ValidateClients(clients ...string) error {
+	return errors.Join(c.clientProblems(clients)...)
+}
+
+func (c *Config) clientProblems(clients []string) []error {
+	var problems []error
+
+	for _, client := range c.knownClients(clients) {
+		for _, problem := range validateClient(clientConfigGetter(client, c)) {
+			problems = append(problems, fmt.Errorf("%s: %s", client, problem))
+		}
+	}
+
+	return problems
+}
+
+func (c *Config) validateServer() []error {
+	var problems []error
+
+	port, err := strconv.Atoi(strings.TrimPrefix(c.Server.Port, ":"))
+	if err != nil || port <= 0 || port > MaxPort {
+		problems = append(problems, fmt.Errorf("server port must be a port number, got %q", c.Server.Port))
+	}
+
+	if c.Server.App.Name == "" {
+		problems = append(problems, errors.New("server app name is required"))
+	}
+
+	if c.Server.ReadTimeout < 0 {
+		problems = append(problems, fmt.Errorf("server read timeout must not be negative, got %v", c.Server.ReadTimeout))
+	}
+
+	if c.Server.WriteTimeout < 0 {
+		problems = append(problems, fmt.Errorf("server write timeout must not be negative, got %v", c.Server.WriteTimeout))
+	}
+
+	// the jwt secret is only read from its secret file outside of local runs
+	if os.Getenv("ENV") != "" && c.Server.JwtSecret == "" {
+		problems = append(problems, errors.New("server jwt secret is required"))
+	}
+
+	return problems
+}
+
+// knownClients returns the clients passed, the built-in clients with an endpoint, which the service does not use
+// otherwise, and, when the client configs are read from the client properties file, the clients with an endpoint in
+// the file. Client names are case-insensitive like the keys of their profiles.
+func (c *Config) knownClients(clients []string) []string {
+	known := map[string]string{}
+	add := func(client string) {
+		if _, ok := known[strings.ToLower(client)]; !ok {
+			known[strings.ToLower(client)] = client
+		}
+	}
+
+	for _, client := range clients {
+		add(client)
+	}
+
+	for _, client := range builtInClients {
+		if clientConfigGetter(client, c)(EndPointSuffix) != "" {
+			add(client)
+		}
+	}
+
+	if c.DynamicConfig == nil && c.AppConfig.AppID == "" {
+		for _, client := range localClientProperties().clientNames() {
+			add(client)
+		}
+	}
+
+	names := make([]string, 0, len(known))
+	for _, client := range known {
+		names = append(names, client)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// validateClient returns the problems of the profile of a client
+func validateClient(get func(suffix string) string) []string {
+	problems := validateEndpoint(get(EndPointSuffix), strings.ToLower(strings.TrimSpace(get(ResolverSuffix))))
+
+	check := func(problem string) {
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	check(validatePositiveDuration(get, TimeoutSuffix, TimeInMs))
+	check(validatePositiveDuration(get, ConnTimeoutSuffix, TimeInMs))
+
+	check(validateCircuitBreakerPercentage(get, CircuitBreakerFailurePercentageThresholdSuffix))
+	check(validatePositiveInteger(get, CircuitBreakerMinExecutionThresholdSuffix))
+	check(validatePositiveDuration(get, CircuitBreakerFailurePeriodThresholdSuffix, TimeInSeconds))
+	check(validatePositiveInteger(get, CircuitBreakerSuccessThresholdSuffix))
+	check(validatePositiveDuration(get, CircuitBreakerDelaySuffix, TimeInMs))
+
+	check(validateNonNegativeInteger(get, RetryMaxRetriesSuffix))
+	check(validatePositiveDuration(get, RetryDelaySuffix, TimeInMs))
+	check(validatePositiveDuration(get, RetryMaxDelaySuffix, TimeInMs))
+	check(validateRetryJitterFactor(get))
+
+	delay, delayErr := time.ParseDuration(join(get(RetryDelaySuffix), TimeInMs))
+	maxDelay, maxDelayErr := time.ParseDuration(join(get(RetryMaxDelaySuffix), TimeInMs))
+
+	if delayErr == nil && maxDelayErr == nil && maxDelay > 0 && maxDelay < delay {
+		check(fmt.Sprintf("%s %v is lower than %s %v", RetryMaxDelaySuffix, maxDelay, RetryDelaySuffix, delay))
+	}
+
+	return problems
+}
+
+func validateEndpoint(endpoint, resolver string) []string {
+	if endpoint == "" {
+		return []string{"endpoint is required"}
+	}
+
+	if resolver == ResolverStatic {
+		for _, addr := range strings.Split(endpoint, ",") {
+			if !isHostPort(strings.TrimSpace(addr)) {
+				return []string{fmt.Sprintf("endpoint %q must be comma separated host:port addresses with the static resolver", endpoint)}
+			}
+		}
+
+		return nil
+	}
+
+	// endpoints which name a scheme are dialed as they are, see the resolvers of the grpc handler
+	valid := isHostPort(endpoint)
+	if strings.Contains(endpoint, "://") {
+		target, err := url.Parse(endpoint)
+		valid = err == nil && target.Scheme != ""
+	}
+
+	if !valid {
+		return []string{fmt.Sprintf("endpoint %q must be host:port or a target with a scheme, e.g. dns:///host:port", endpoint)}
+	}
+
+	return nil
+}
+
+func isHostPort(addr string) bool {
+	host, portConfig, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+
+	port, err := strconv.Atoi(portConfig)
+
+	return err == nil && port > 0 && port <= MaxPort
+}
+
+func validatePositiveDuration(get func(suffix string) string, suffix, unit string) string {
+	valConfig := get(suffix)
+	if valConfig == "" {
+		return ""
+	}
+
+	if val, err := time.ParseDuration(join(valConfig, unit)); err != nil || val <= 0 {
+		return fmt.Sprintf("%s must be a positive duration in %s, got %q", suffix, unit, valConfig)
+	}
+
+	return ""
+}
+
+func validatePositiveInteger(get func(suffix string) string, suffix string) string {
+	valConfig := get(suffix)
+	if valConfig == "" {
+		return ""
+	}
+
+	if val, err := strconv.ParseUint(valConfig, Base10, BitSize32); err != nil || val == 0 {
+		return fmt.Sprintf("%s must be a positive integer, got %q", suffix, valConfig)
+	}
+
+	return ""
+}
+
+func validateNonNegativeInteger(get func(suffix string) string, suffix string) string {
+	valConfig := get(suffix)
+	if valConfig == "" {
+		return ""
+	}
+
+	if _, err := strconv.ParseUint(valConfig, Base10, BitSize32); err != nil {
+		return fmt.Sprintf("%s must be a non-negative integer, got %q", suffix, valConfig)
+	}
+
+	return ""
+}
+
+func validateCircuitBreakerPercentage(get func(suffix string) string, suffix string) string {
+	valConfig := get(suffix)
+	if valConfig == "" {
+		return ""
+	}
+
+	if val, err := strconv.ParseUint(valConfig, Base10, BitSize32); err != nil || val == 0 || val > MaxCircuitBreakerPercentage {
+		return fmt.Sprintf("%s must be within [1, %d], got %q", suffix, MaxCircuitBreakerPercentage, valConfig)
+	}
+
+	return ""
+}
+
+func validateRetryJitterFactor(get func(suffix string) string) string {
+	valConfig := get(RetryJitterFactorSuffix)
+	if valConfig == "" {
+		return ""
+	}
+
+	if val, err := strconv.ParseFloat(valConfig, BitSize64); err != nil || val < 0 || val > MaxRetryJitterFactor {
+		return fmt.Sprintf("%s must be within [0, %d], got %q", RetryJitterFactorSuffix, MaxRetryJitterFactor, valConfig)
+	}
+
+	return ""
+}
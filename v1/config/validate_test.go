@@ -0,0 +1,118 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validServerConfig() ServerConfig {
+	return ServerConfig{Port: ":8080", App: App{Name: "bff"}}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		server       ServerConfig
+		values       map[string]string
+		wantProblems []string
+	}{
+		{
+			name:   "valid config",
+			server: validServerConfig(),
+			values: map[string]string{
+				"page-client" + EndPointSuffix:                                 "page:9090",
+				"page-client" + TimeoutSuffix:                                  "200",
+				"page-client" + CircuitBreakerFailurePercentageThresholdSuffix: "50",
+				"page-client" + RetryMaxRetriesSuffix:                          "0",
+				"user-client" + EndPointSuffix:                                 "dns:///user:9090",
+			},
+		},
+		{
+			name:   "static resolver with several addresses",
+			server: validServerConfig(),
+			values: map[string]string{
+				"page-client" + EndPointSuffix: "10.0.0.1:9090, 10.0.0.2:9090",
+				"page-client" + ResolverSuffix: ResolverStatic,
+				"user-client" + EndPointSuffix: "user:9090",
+			},
+		},
+		{
+			name:   "every problem is reported at once",
+			server: ServerConfig{Port: "http"},
+			values: map[string]string{
+				"page-client" + EndPointSuffix:                                 "page",
+				"page-client" + TimeoutSuffix:                                  "0",
+				"page-client" + CircuitBreakerFailurePercentageThresholdSuffix: "150",
+				"page-client" + CircuitBreakerSuccessThresholdSuffix:           "0",
+				"page-client" + RetryMaxRetriesSuffix:                          "-1",
+				"page-client" + RetryDelaySuffix:                               "500",
+				"page-client" + RetryMaxDelaySuffix:                            "100",
+				"page-client" + RetryJitterFactorSuffix:                        "2",
+			},
+			wantProblems: []string{
+				`server port must be a port number, got "http"`,
+				"server app name is required",
+				`page-client: endpoint "page" must be host:port or a target with a scheme, e.g. dns:///host:port`,
+				`page-client: .timeout must be a positive duration in ms, got "0"`,
+				`page-client: .cb.failure_percentage_threshold must be within [1, 100], got "150"`,
+				`page-client: .cb.success_threshold must be a positive integer, got "0"`,
+				`page-client: .retry.max_retries must be a non-negative integer, got "-1"`,
+				`page-client: .retry.jitter_factor must be within [0, 1], got "2"`,
+				"page-client: .retry.max_delay 100ms is lower than .retry.delay 500ms",
+				"user-client: endpoint is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := NewMockDynamicConfig(ctrl)
+			dc.EXPECT().Get(gomock.Any()).DoAndReturn(func(key string) (string, error) {
+				return tt.values[key], nil
+			}).AnyTimes()
+
+			cnf := &Config{Server: tt.server, DynamicConfig: dc}
+			err := cnf.Validate("user-client", "page-client")
+
+			if len(tt.wantProblems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantProblems, strings.Split(err.Error(), "\n"))
+		})
+	}
+}
+
+func TestConfig_ValidateClients(t *testing.T) {
+	values := map[string]string{
+		"pageServiceConfig" + EndPointSuffix: "page",
+		"pageServiceConfig" + TimeoutSuffix:  "0",
+	}
+
+	ctrl := gomock.NewController(t)
+	dc := NewMockDynamicConfig(ctrl)
+	dc.EXPECT().Get(gomock.Any()).DoAndReturn(func(key string) (string, error) {
+		return values[key], nil
+	}).AnyTimes()
+
+	// client profiles read from App Config are only validated once the dynamic config is initialised
+	cnf := &Config{Server: validServerConfig(), AppConfig: AppConfig{AppID: "bff"}}
+	assert.NoError(t, cnf.Validate("user-client"))
+
+	// built-in clients without an endpoint are not used by the service, the clients passed are always validated
+	cnf.DynamicConfig = dc
+	err := cnf.ValidateClients("user-client")
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		`pageServiceConfig: endpoint "page" must be host:port or a target with a scheme, e.g. dns:///host:port`,
+		`pageServiceConfig: .timeout must be a positive duration in ms, got "0"`,
+		"user-client: endpoint is required",
+	}, strings.Split(err.Error(), "\n"))
+}
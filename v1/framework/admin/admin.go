@@ -0,0 +1,123 @@
+// Package admin serves the endpoints used by on-call engineers to inspect and override the resilience policies of a bff
+// service during incidents.
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+const (
+	BreakersPath        = "/admin/circuit-breakers"
+	BreakerOverridePath = "/admin/circuit-breakers/override"
+	BreakerResetPath    = "/admin/circuit-breakers/reset"
+	ConfigPath          = "/admin/config"
+
+	ConfigKeyParam = "key"
+)
+
+// OverrideRequest forces the circuit breakers of the method of the client, or of every method of the client when the
+// method is empty, open or closed for the ttl
+type OverrideRequest struct {
+	Client string `json:"client"`
+	Method string `json:"method"`
+	State  string `json:"state"`
+	TTLMs  int64  `json:"ttl_ms"`
+}
+
+// ResetRequest removes the overrides of the circuit breakers of the method of the client, or of every method of the
+// client when the method is empty, and closes them
+type ResetRequest struct {
+	Client string `json:"client"`
+	Method string `json:"method"`
+}
+
+type BreakersResponse struct {
+	Breakers []grpc.BreakerStatus `json:"breakers"`
+}
+
+type ConfigResponse struct {
+	Sources []config.Source `json:"sources"`
+}
+
+type Handler struct {
+	breakers BreakerRegistry
+	sources  ConfigSources
+	logger   logger.Logger
+}
+
+func NewHandler(breakers BreakerRegistry, sources ConfigSources, log logger.Logger) *Handler {
+	return &Handler{breakers: breakers, sources: sources, logger: log}
+}
+
+// Breakers lists the state, failure statistics, time in state, config and override of every circuit breaker
+func (h *Handler) Breakers(c echo.Context) error {
+	return c.JSON(http.StatusOK, BreakersResponse{Breakers: h.breakers.List()})
+}
+
+// OverrideBreaker forces circuit breakers open or closed, it responds with http.StatusNotFound when the client has no
+// circuit breaker for the method
+func (h *Handler) OverrideBreaker(c echo.Context) error {
+	var req OverrideRequest
+	if err := c.Bind(&req); err != nil || req.Client == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "client is required")
+	}
+
+	override, err := h.breakers.Force(req.Client, req.Method, req.State, time.Duration(req.TTLMs)*time.Millisecond)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	h.logger.WithContext(c).Warnf("circuit breaker of client-%s method-%s forced %s until %v by user-%v",
+		req.Client, req.Method, override.State, override.Until, c.Get(utils.UserID))
+
+	return c.JSON(http.StatusOK, override)
+}
+
+// ResetBreaker removes the overrides of circuit breakers and closes them with fresh failure statistics
+func (h *Handler) ResetBreaker(c echo.Context) error {
+	var req ResetRequest
+	if err := c.Bind(&req); err != nil || req.Client == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "client is required")
+	}
+
+	if err := h.breakers.Reset(req.Client, req.Method); err != nil {
+		return toHTTPError(err)
+	}
+
+	h.logger.WithContext(c).Warnf("circuit breaker of client-%s method-%s reset by user-%v", req.Client, req.Method, c.Get(utils.UserID))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Config lists the value and the layer of every resolved config key with the secret values redacted, or explains the
+// key of the query param, it responds with http.StatusNotFound when the key was never resolved
+func (h *Handler) Config(c echo.Context) error {
+	key := c.QueryParam(ConfigKeyParam)
+	if key == "" {
+		return c.JSON(http.StatusOK, ConfigResponse{Sources: h.sources.Dump()})
+	}
+
+	source, ok := h.sources.Explain(key)
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "config key not resolved: "+key)
+	}
+
+	return c.JSON(http.StatusOK, source)
+}
+
+func toHTTPError(err error) error {
+	if errors.Is(err, grpc.ErrBreakerNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+}
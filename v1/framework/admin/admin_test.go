@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+func newTestLogger() logger.Logger {
+	var log logger.Logger = logger.NewAPILogger(&config.Config{Logger: config.Logger{Level: "error"}})
+	log.InitLogger()
+
+	return log
+}
+
+func newJSONContext(method, path, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	return echo.New().NewContext(req, rec), rec
+}
+
+func TestHandler_Breakers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	breakers := NewMockBreakerRegistry(ctrl)
+	breakers.EXPECT().List().Return([]grpc.BreakerStatus{
+		{Client: "page-client", Method: "/page.Page/GetPage", State: "open", FailureRate: 60, Executions: 10, Failures: 6, TimeInStateMs: 1500},
+	})
+
+	c, rec := newJSONContext(http.MethodGet, BreakersPath, "")
+	require.NoError(t, NewHandler(breakers, nil, newTestLogger()).Breakers(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"client":"page-client","method":"/page.Page/GetPage","state":"open","failure_rate":60`)
+	assert.Contains(t, rec.Body.String(), `"time_in_state_ms":1500`)
+}
+
+func TestHandler_OverrideBreaker(t *testing.T) {
+	until := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		body     string
+		forceErr error
+		wantCode int
+	}{
+		{
+			name:     "breaker forced open",
+			body:     `{"client":"page-client","method":"/page.Page/GetPage","state":"open","ttl_ms":60000}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "client without breaker",
+			body:     `{"client":"page-client","method":"/page.Page/GetPage","state":"open","ttl_ms":60000}`,
+			forceErr: grpc.ErrBreakerNotFound,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid override",
+			body:     `{"client":"page-client","method":"/page.Page/GetPage","state":"open","ttl_ms":60000}`,
+			forceErr: errors.New("override ttl must be within (0, 1h0m0s]"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "request without client",
+			body:     `{"state":"open","ttl_ms":60000}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			breakers := NewMockBreakerRegistry(ctrl)
+
+			if strings.Contains(tt.body, "client") {
+				breakers.EXPECT().Force("page-client", "/page.Page/GetPage", grpc.BreakerForcedOpen, time.Minute).
+					Return(grpc.BreakerOverride{State: grpc.BreakerForcedOpen, Until: until}, tt.forceErr)
+			}
+
+			c, rec := newJSONContext(http.MethodPost, BreakerOverridePath, tt.body)
+			err := NewHandler(breakers, nil, newTestLogger()).OverrideBreaker(c)
+
+			if tt.wantCode != http.StatusOK {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tt.wantCode, httpErr.Code)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"state":"open","until":"2026-01-01T10:00:00Z"}`, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_ResetBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	breakers := NewMockBreakerRegistry(ctrl)
+	breakers.EXPECT().Reset("page-client", "").Return(nil)
+	breakers.EXPECT().Reset("user-client", "").Return(grpc.ErrBreakerNotFound)
+
+	h := NewHandler(breakers, nil, newTestLogger())
+
+	c, rec := newJSONContext(http.MethodPost, BreakerResetPath, `{"client":"page-client"}`)
+	require.NoError(t, h.ResetBreaker(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	c, _ = newJSONContext(http.MethodPost, BreakerResetPath, `{"client":"user-client"}`)
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, h.ResetBreaker(c), &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
+
+func TestHandler_Config(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sources := NewMockConfigSources(ctrl)
+	sources.EXPECT().Dump().Return([]config.Source{
+		{Key: "page-client.timeout", Value: "3000", Layer: config.LayerDynamic},
+		{Key: "server.jwtSecret", Value: config.RedactedValue, Layer: config.LayerFile},
+	})
+	sources.EXPECT().Explain("page-client.timeout").Return(config.Source{Key: "page-client.timeout", Value: "3000", Layer: config.LayerDynamic}, true)
+	sources.EXPECT().Explain("page-client.conn_timeout").Return(config.Source{}, false)
+
+	h := NewHandler(nil, sources, newTestLogger())
+
+	c, rec := newJSONContext(http.MethodGet, ConfigPath, "")
+	require.NoError(t, h.Config(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"sources":[{"key":"page-client.timeout","value":"3000","layer":"dynamic"},`+
+		`{"key":"server.jwtSecret","value":"<redacted>","layer":"file"}]}`, rec.Body.String())
+
+	c, rec = newJSONContext(http.MethodGet, ConfigPath+"?key=page-client.timeout", "")
+	require.NoError(t, h.Config(c))
+	assert.JSONEq(t, `{"key":"page-client.timeout","value":"3000","layer":"dynamic"}`, rec.Body.String())
+
+	c, _ = newJSONContext(http.MethodGet, ConfigPath+"?key=page-client.conn_timeout", "")
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, h.Config(c), &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+)
+
+type BreakerRegistry interface {
+	List() []grpc.BreakerStatus
+	Force(client, method, state string, ttl time.Duration) (grpc.BreakerOverride, error)
+	Reset(client, method string) error
+}
+
+type ConfigSources interface {
+	Explain(key string) (config.Source, bool)
+	Dump() []config.Source
+}
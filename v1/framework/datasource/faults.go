@@ -0,0 +1,41 @@
+package datasource
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/faults"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/httperr"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+// FaultInjectionFilter injects the latency and errors of the matching fault injection rules into the executions of the
+// datasource, so that the fallbacks of the widgets can be tested. It never injects faults when ENV is prod.
+func FaultInjectionFilter(injector *faults.Injector, dsName string, log logger.Logger) Filter {
+	return func(c echo.Context) error {
+		rule, inject := injector.MatchDataSource(dsName)
+		if !inject {
+			return nil
+		}
+
+		log.WithContext(c).Infof("injecting fault into datasource: %s, rule: %+v", dsName, rule)
+
+		if err := faults.Sleep(c.Request().Context(), rule); err != nil {
+			return err
+		}
+
+		if rule.HTTPStatus != 0 {
+			return httperr.NewRestError(rule.HTTPStatus, http.StatusText(rule.HTTPStatus), "fault injected into datasource: "+dsName)
+		}
+
+		if rule.Code != codes.OK {
+			status := utils.GetHTTPStatusCode(rule.Code)
+			return httperr.NewRestError(status, http.StatusText(status), "fault injected into datasource: "+dsName)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,276 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+)
+
+const (
+	BreakerForcedOpen   = "open"
+	BreakerForcedClosed = "closed"
+
+	// MaxBreakerOverrideTTL bounds the overrides, so that a forgotten override does not outlive the incident
+	MaxBreakerOverrideTTL = time.Hour
+)
+
+var ErrBreakerNotFound = errors.New("circuit breaker not found")
+
+// BreakerOverride forces the circuit breakers of a client, or of a method of the client, open or closed until it expires
+type BreakerOverride struct {
+	State string    `json:"state"`
+	Until time.Time `json:"until"`
+}
+
+// BreakerStatus is the state of the circuit breaker of a method of a client
+type BreakerStatus struct {
+	Client        string                            `json:"client"`
+	Method        string                            `json:"method"`
+	State         string                            `json:"state"`
+	FailureRate   uint                              `json:"failure_rate"`
+	Executions    uint                              `json:"executions"`
+	Failures      uint                              `json:"failures"`
+	TimeInStateMs int64                             `json:"time_in_state_ms"`
+	Config        config.CircuitBreakerClientConfig `json:"config"`
+	Override      *BreakerOverride                  `json:"override,omitempty"`
+}
+
+type breakerEntry struct {
+	client     string
+	method     string
+	cb         circuitbreaker.CircuitBreaker[any]
+	config     config.CircuitBreakerClientConfig
+	stateSince time.Time
+	resetting  bool
+}
+
+// Breakers keeps the circuit breakers of every client, so that they can be inspected and overridden during incidents.
+// Overrides are kept by client and method, an override without a method applies to every method of the client, and
+// they apply to the breakers created after them as well, e.g. when the connection of a client is rebuilt.
+type Breakers struct {
+	mu        sync.RWMutex
+	entries   map[string]*breakerEntry
+	overrides map[string]BreakerOverride
+	now       func() time.Time
+}
+
+func NewBreakers() *Breakers {
+	return &Breakers{entries: map[string]*breakerEntry{}, overrides: map[string]BreakerOverride{}, now: time.Now}
+}
+
+//nolint:gochecknoglobals // the circuit breakers are created lazily by the connections of every client
+var defaultBreakers = NewBreakers()
+
+// DefaultBreakers returns the circuit breakers of the connections created by the grpc handler
+func DefaultBreakers() *Breakers {
+	return defaultBreakers
+}
+
+func breakerKey(client, method string) string {
+	return client + "|" + method
+}
+
+// register keeps the circuit breaker of the method of the client, replacing the breaker of a previous connection, and
+// applies the override of the method
+func (b *Breakers) register(client, method string, cb circuitbreaker.CircuitBreaker[any], cfg config.CircuitBreakerClientConfig) {
+	b.mu.Lock()
+	b.entries[breakerKey(client, method)] = &breakerEntry{client: client, method: method, cb: cb, config: cfg, stateSince: b.now()}
+	override, ok := b.overrideLocked(client, method)
+	b.mu.Unlock()
+
+	// transitions notify stateChanged, so they are made without the lock
+	if ok {
+		applyOverride(cb, override.State)
+	}
+}
+
+// stateChanged records the time the circuit breaker of the method of the client changed its state
+func (b *Breakers) stateChanged(client, method string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if entry, ok := b.entries[breakerKey(client, method)]; ok {
+		entry.stateSince = b.now()
+	}
+}
+
+// reporting reports whether the state changes of the circuit breaker of the method of the client are logged and
+// recorded, they are not while the breaker is reset, see Reset
+func (b *Breakers) reporting(client, method string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	entry, ok := b.entries[breakerKey(client, method)]
+
+	return !ok || !entry.resetting
+}
+
+// forcedOpen reports whether the circuit breaker of the method of the client is forced open, a breaker forced open
+// rejects every call until the override expires, even once its delay has elapsed
+func (b *Breakers) forcedOpen(client, method string) bool {
+	return b.forcedState(client, method) == BreakerForcedOpen
+}
+
+// forcedState returns the state the circuit breaker of the method of the client is forced to, or an empty string
+func (b *Breakers) forcedState(client, method string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	override, _ := b.overrideLocked(client, method)
+
+	return override.State
+}
+
+// overrideLocked returns the override of the method, or else the override of the client, which has not expired
+func (b *Breakers) overrideLocked(client, method string) (BreakerOverride, bool) {
+	for _, key := range []string{breakerKey(client, method), breakerKey(client, "")} {
+		if override, ok := b.overrides[key]; ok && b.now().Before(override.Until) {
+			return override, true
+		}
+	}
+
+	return BreakerOverride{}, false
+}
+
+// List returns the status of every circuit breaker, sorted by client and method
+func (b *Breakers) List() []BreakerStatus {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	statuses := make([]BreakerStatus, 0, len(b.entries))
+
+	for _, entry := range b.entries {
+		cbMetrics := entry.cb.Metrics()
+		status := BreakerStatus{
+			Client:        entry.client,
+			Method:        entry.method,
+			State:         entry.cb.State().String(),
+			FailureRate:   cbMetrics.FailureRate(),
+			Executions:    cbMetrics.Executions(),
+			Failures:      cbMetrics.Failures(),
+			TimeInStateMs: b.now().Sub(entry.stateSince).Milliseconds(),
+			Config:        entry.config,
+		}
+
+		if override, ok := b.overrideLocked(entry.client, entry.method); ok {
+			status.Override = &override
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Client != statuses[j].Client {
+			return statuses[i].Client < statuses[j].Client
+		}
+
+		return statuses[i].Method < statuses[j].Method
+	})
+
+	return statuses
+}
+
+// Force forces the circuit breakers of the method of the client open or closed for the ttl, every method of the client
+// when the method is empty. A breaker forced open rejects every call, a breaker forced closed records no failure.
+func (b *Breakers) Force(client, method, state string, ttl time.Duration) (BreakerOverride, error) {
+	if state != BreakerForcedOpen && state != BreakerForcedClosed {
+		return BreakerOverride{}, fmt.Errorf("unsupported circuit breaker state: %s", state)
+	}
+
+	if ttl <= 0 || ttl > MaxBreakerOverrideTTL {
+		return BreakerOverride{}, fmt.Errorf("override ttl must be within (0, %v]", MaxBreakerOverrideTTL)
+	}
+
+	b.mu.Lock()
+	entries := b.matchLocked(client, method)
+	override := BreakerOverride{State: state, Until: b.now().Add(ttl)}
+
+	if len(entries) > 0 {
+		b.overrides[breakerKey(client, method)] = override
+	}
+	b.mu.Unlock()
+
+	if len(entries) == 0 {
+		return BreakerOverride{}, ErrBreakerNotFound
+	}
+
+	for _, entry := range entries {
+		applyOverride(entry.cb, state)
+	}
+
+	return override, nil
+}
+
+// Reset removes the overrides of the method of the client, every method of the client when the method is empty, and
+// closes their circuit breakers with fresh failure statistics
+func (b *Breakers) Reset(client, method string) error {
+	b.mu.Lock()
+	entries := b.matchLocked(client, method)
+
+	for key := range b.overrides {
+		if key == breakerKey(client, method) || (method == "" && strings.HasPrefix(key, breakerKey(client, ""))) {
+			delete(b.overrides, key)
+		}
+	}
+	b.mu.Unlock()
+
+	if len(entries) == 0 {
+		return ErrBreakerNotFound
+	}
+
+	for _, entry := range entries {
+		b.resetBreaker(entry)
+	}
+
+	return nil
+}
+
+// resetBreaker closes the circuit breaker with fresh failure statistics. The statistics are only cleared by a
+// transition to the closed state, so a closed breaker is opened and closed again without reporting the transitions,
+// they would page on-call for a breaker which never opened.
+func (b *Breakers) resetBreaker(entry *breakerEntry) {
+	if !entry.cb.IsClosed() {
+		entry.cb.Close()
+		return
+	}
+
+	b.setResetting(entry, true)
+	defer b.setResetting(entry, false)
+
+	entry.cb.Open()
+	entry.cb.Close()
+}
+
+func (b *Breakers) setResetting(entry *breakerEntry, resetting bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry.resetting = resetting
+}
+
+func (b *Breakers) matchLocked(client, method string) []*breakerEntry {
+	var entries []*breakerEntry
+
+	for _, entry := range b.entries {
+		if entry.client == client && (method == "" || entry.method == method) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+func applyOverride(cb circuitbreaker.CircuitBreaker[any], state string) {
+	if state == BreakerForcedOpen {
+		cb.Open()
+	} else {
+		cb.Close()
+	}
+}
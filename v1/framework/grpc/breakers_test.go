@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+)
+
+func newTestBreakers(now *time.Time) *Breakers {
+	b := NewBreakers()
+	b.now = func() time.Time { return *now }
+
+	return b
+}
+
+func newTestBreaker(b *Breakers, client, method string) circuitbreaker.CircuitBreaker[any] {
+	cb := circuitbreaker.Builder[any]().
+		WithFailureThreshold(5).
+		WithDelay(time.Minute).
+		OnStateChanged(func(circuitbreaker.StateChangedEvent) { b.stateChanged(client, method) }).
+		Build()
+	b.register(client, method, cb, config.CircuitBreakerClientConfig{Delay: time.Minute})
+
+	return cb
+}
+
+func TestBreakers_List(t *testing.T) {
+	now := time.Now()
+	b := newTestBreakers(&now)
+
+	userCB := newTestBreaker(b, "user-client", "/user.User/GetUser")
+	newTestBreaker(b, "page-client", "/page.Page/GetPage")
+
+	userCB.RecordFailure()
+	userCB.RecordSuccess()
+	now = now.Add(time.Second)
+
+	statuses := b.List()
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "page-client", statuses[0].Client)
+	assert.Equal(t, BreakerStatus{
+		Client:        "user-client",
+		Method:        "/user.User/GetUser",
+		State:         "closed",
+		FailureRate:   50,
+		Executions:    2,
+		Failures:      1,
+		TimeInStateMs: 1000,
+		Config:        config.CircuitBreakerClientConfig{Delay: time.Minute},
+	}, statuses[1])
+}
+
+func TestBreakers_Force(t *testing.T) {
+	now := time.Now()
+	b := newTestBreakers(&now)
+
+	getPage := newTestBreaker(b, "page-client", "/page.Page/GetPage")
+	listPages := newTestBreaker(b, "page-client", "/page.Page/ListPages")
+
+	_, err := b.Force("page-client", "", "half-open", time.Minute)
+	assert.Error(t, err)
+	_, err = b.Force("page-client", "", BreakerForcedOpen, 2*time.Hour)
+	assert.Error(t, err)
+	_, err = b.Force("user-client", "", BreakerForcedOpen, time.Minute)
+	assert.ErrorIs(t, err, ErrBreakerNotFound)
+
+	override, err := b.Force("page-client", "", BreakerForcedOpen, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, BreakerOverride{State: BreakerForcedOpen, Until: now.Add(time.Minute)}, override)
+	assert.True(t, getPage.IsOpen())
+	assert.True(t, listPages.IsOpen())
+	assert.True(t, b.forcedOpen("page-client", "/page.Page/GetPage"))
+
+	// the override of a method takes precedence over the override of its client
+	_, err = b.Force("page-client", "/page.Page/ListPages", BreakerForcedClosed, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, listPages.IsClosed())
+	assert.Equal(t, BreakerForcedClosed, b.forcedState("page-client", "/page.Page/ListPages"))
+
+	// the breakers of a rebuilt connection keep the override
+	rebuilt := newTestBreaker(b, "page-client", "/page.Page/GetPage")
+	assert.True(t, rebuilt.IsOpen())
+
+	now = now.Add(time.Minute)
+	assert.Empty(t, b.forcedState("page-client", "/page.Page/GetPage"), "override expired")
+	assert.Nil(t, b.List()[0].Override)
+}
+
+func TestBreakers_Reset(t *testing.T) {
+	now := time.Now()
+	b := newTestBreakers(&now)
+
+	cb := newTestBreaker(b, "page-client", "/page.Page/GetPage")
+	for range 3 {
+		cb.RecordError(errors.New("unavailable"))
+	}
+
+	_, err := b.Force("page-client", "/page.Page/GetPage", BreakerForcedOpen, time.Minute)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, b.Reset("user-client", ""), ErrBreakerNotFound)
+	require.NoError(t, b.Reset("page-client", ""))
+
+	assert.True(t, cb.IsClosed())
+	assert.Zero(t, cb.Metrics().Failures())
+	assert.Empty(t, b.forcedState("page-client", "/page.Page/GetPage"))
+}
+
+func TestBreakers_ResetReportsOnlyRealTransitions(t *testing.T) {
+	now := time.Now()
+	b := newTestBreakers(&now)
+
+	var reported []circuitbreaker.StateChangedEvent
+	newReportingBreaker := func(method string) circuitbreaker.CircuitBreaker[any] {
+		cb := circuitbreaker.Builder[any]().
+			WithFailureThreshold(5).
+			WithDelay(time.Minute).
+			OnStateChanged(func(event circuitbreaker.StateChangedEvent) {
+				if b.reporting("page-client", method) {
+					reported = append(reported, event)
+				}
+			}).
+			Build()
+		b.register("page-client", method, cb, config.CircuitBreakerClientConfig{Delay: time.Minute})
+
+		return cb
+	}
+
+	closedCB := newReportingBreaker("/page.Page/GetPage")
+	closedCB.RecordFailure()
+
+	require.NoError(t, b.Reset("page-client", "/page.Page/GetPage"))
+	assert.True(t, closedCB.IsClosed())
+	assert.Zero(t, closedCB.Metrics().Failures())
+	assert.Empty(t, reported, "a closed breaker is reset without reporting transitions")
+
+	openCB := newReportingBreaker("/page.Page/ListPages")
+	openCB.Open()
+	reported = nil
+
+	require.NoError(t, b.Reset("page-client", "/page.Page/ListPages"))
+	assert.True(t, openCB.IsClosed())
+	require.Len(t, reported, 1)
+	assert.Equal(t, circuitbreaker.OpenState, reported[0].OldState)
+	assert.Equal(t, circuitbreaker.ClosedState, reported[0].NewState)
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+)
+
+// ErrBulkheadFull is returned for the calls rejected by the bulkhead of a client, its Unavailable code maps to
+// http.StatusServiceUnavailable. The calls are rejected locally, so they are not served with the last good responses of
+// their methods like the calls to an unavailable downstream, see isFallbackError.
+var ErrBulkheadFull = status.Error(codes.Unavailable, "too many concurrent calls to the downstream, bulkhead is full")
+
+// newBulkheadUnaryInterceptor limits the calls in flight to the downstream of a client, so that a slow downstream can
+// only tie up a bounded number of goroutines. It runs inside the propagation, fallback and metrics interceptors and
+// outside the failsafe interceptor, so a call holds its permit for all of its retries and hedges.
+func newBulkheadUnaryInterceptor(client string, bulkheadConfig config.BulkheadClientConfig,
+	bulkheadMetrics *metrics.BulkheadMetrics) grpc.UnaryClientInterceptor {
+	var rejectedCount metric.Int64Counter
+	if bulkheadMetrics != nil {
+		rejectedCount = bulkheadMetrics.RejectedCount
+	}
+
+	executor := failsafe.NewExecutor[any](bulkhead.Builder[any](bulkheadConfig.MaxConcurrency).
+		WithMaxWaitTime(bulkheadConfig.MaxWait).
+		OnFull(func(event failsafe.ExecutionEvent[any]) {
+			if rejectedCount == nil {
+				return
+			}
+
+			ctx := event.Context()
+
+			paramsMap := map[string]string{
+				clientNameAttr: client,
+				methodAttr:     methodFromContext(ctx),
+			}
+			metrics.AddCounter(rejectedCount, ctx, "Bulkhead", paramsMap)
+		}).
+		Build())
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = context.WithValue(ctx, methodCtxKey{}, method)
+		err := executor.WithContext(ctx).Run(func() error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+
+		if errors.Is(err, bulkhead.ErrFull) {
+			return ErrBulkheadFull
+		}
+
+		return err
+	}
+}
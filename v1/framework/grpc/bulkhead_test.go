@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_newBulkheadUnaryInterceptor(t *testing.T) {
+	bulkheadMetrics, err := metrics.NewBulkheadMetrics(noop.NewMeterProvider().Meter("bulkhead-test"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		maxWait time.Duration
+		hold    time.Duration
+		wantErr error
+	}{
+		{
+			name:    "call is rejected while the bulkhead is full",
+			hold:    100 * time.Millisecond,
+			wantErr: ErrBulkheadFull,
+		},
+		{
+			name:    "call waits for a permit within max wait",
+			maxWait: time.Second,
+			hold:    20 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := newBulkheadUnaryInterceptor("test-client",
+				config.BulkheadClientConfig{MaxConcurrency: 1, MaxWait: tt.maxWait}, bulkheadMetrics)
+
+			started, done := make(chan struct{}), make(chan error)
+			go func() {
+				done <- interceptor(context.Background(), "/user.User/GetUser", nil, nil, nil,
+					func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+						close(started)
+						time.Sleep(tt.hold)
+						return nil
+					})
+			}()
+			<-started
+
+			err := interceptor(context.Background(), "/user.User/GetUser", nil, nil, nil,
+				func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+					return nil
+				})
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, <-done)
+		})
+	}
+}
+
+func TestErrBulkheadFullStatus(t *testing.T) {
+	assert.Equal(t, codes.Unavailable, status.Code(ErrBulkheadFull))
+
+	respCode, _ := utils.GetErrorCodeAndMessage(ErrBulkheadFull)
+	assert.Equal(t, http.StatusServiceUnavailable, respCode)
+}
@@ -0,0 +1,228 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+// interaction is a recorded call, its request, response and status are protojson encoded
+type interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Status   json.RawMessage `json:"status,omitempty"`
+}
+
+// cassette holds the recorded calls of a client, the calls replayed so far are marked as used
+type cassette struct {
+	path string
+
+	mu           sync.Mutex
+	interactions []interaction
+	used         []bool
+}
+
+//nolint:gochecknoglobals // cassettes are shared by the connections of a pool and the connections rebuilt on reload
+var (
+	cassettesMu sync.Mutex
+	cassettes   = map[string]*cassette{}
+)
+
+// openCassette returns the cassette of the path, a recorded cassette starts empty the first time it is opened and a
+// replayed cassette is read from its file
+func openCassette(path, mode string) (*cassette, error) {
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+
+	key := mode + "|" + path
+	if c, ok := cassettes[key]; ok {
+		return c, nil
+	}
+
+	c := &cassette{path: path}
+
+	if mode == config.CassetteModeReplay {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+
+		if err := json.Unmarshal(raw, &c.interactions); err != nil {
+			return nil, fmt.Errorf("error parsing cassette %s: %w", path, err)
+		}
+
+		c.used = make([]bool, len(c.interactions))
+	}
+
+	cassettes[key] = c
+
+	return c, nil
+}
+
+// record appends the call to the cassette and rewrites its file, so that the cassette is complete whenever the
+// process stops
+func (c *cassette) record(method string, req, reply any, callErr error) error {
+	in := interaction{Method: method}
+
+	var err error
+	if in.Request, err = marshalProto(req); err != nil {
+		return err
+	}
+
+	if callErr == nil {
+		if in.Response, err = marshalProto(reply); err != nil {
+			return err
+		}
+	} else if in.Status, err = protojson.Marshal(status.Convert(callErr).Proto()); err != nil {
+		return fmt.Errorf("error encoding status: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, in)
+
+	return c.write()
+}
+
+func (c *cassette) write() error {
+	raw, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("error creating cassette dir: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+
+	return os.Rename(tmp, c.path)
+}
+
+// replay serves the call from the first unused recording of the method with an equal request, the last such recording
+// is served again once all of them are used
+func (c *cassette) replay(method string, req, reply any) error {
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "cassette: request of %s is not a proto message", method)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+
+	for i, in := range c.interactions {
+		if in.Method != method || !c.requestEquals(in.Request, reqMsg) {
+			continue
+		}
+
+		match = i
+		if !c.used[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return status.Errorf(codes.Internal, "cassette: no recorded interaction for %s in %s", method, c.path)
+	}
+
+	c.used[match] = true
+	in := c.interactions[match]
+
+	if len(in.Status) > 0 {
+		st := &spb.Status{}
+		if err := protojson.Unmarshal(in.Status, st); err != nil {
+			return status.Errorf(codes.Internal, "cassette: error decoding status of %s: %v", method, err)
+		}
+
+		if err := status.ErrorProto(st); err != nil {
+			return err
+		}
+	}
+
+	replyMsg, ok := reply.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "cassette: response of %s is not a proto message", method)
+	}
+
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(in.Response, replyMsg); err != nil {
+		return status.Errorf(codes.Internal, "cassette: error decoding response of %s: %v", method, err)
+	}
+
+	return nil
+}
+
+// requestEquals compares the recorded request with the request of the call as messages, protojson output is not stable
+// enough to compare it as text
+func (c *cassette) requestEquals(recorded json.RawMessage, req proto.Message) bool {
+	msg := req.ProtoReflect().New().Interface()
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(recorded, msg); err != nil {
+		return false
+	}
+
+	return proto.Equal(msg, req)
+}
+
+func marshalProto(v any) (json.RawMessage, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cassette: %T is not a proto message", v)
+	}
+
+	raw, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %T: %w", v, err)
+	}
+
+	return raw, nil
+}
+
+// newCassetteUnaryInterceptor records the calls of the client to its cassette file, or replays them from it without
+// calling the downstream. It is the innermost interceptor of the client, so that replayed calls go through the same
+// metrics, circuit breaker and retries as live calls.
+func newCassetteUnaryInterceptor(log logger.Logger, client string, cassetteConfig config.CassetteClientConfig) (grpc.UnaryClientInterceptor, error) {
+	switch cassetteConfig.Mode {
+	case config.CassetteModeRecord, config.CassetteModeReplay:
+	default:
+		return nil, fmt.Errorf("unsupported cassette mode: %s", cassetteConfig.Mode)
+	}
+
+	c, err := openCassette(filepath.Join(cassetteConfig.Dir, client+".json"), cassetteConfig.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if cassetteConfig.Mode == config.CassetteModeReplay {
+		return func(_ context.Context, method string, req, reply any, _ *grpc.ClientConn, _ grpc.UnaryInvoker, _ ...grpc.CallOption) error {
+			return c.replay(method, req, reply)
+		}, nil
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if recordErr := c.record(method, req, reply, err); recordErr != nil {
+			log.Errorf("error recording call of client: %s, method: %s, err: %v", client, method, recordErr)
+		}
+
+		return err
+	}, nil
+}
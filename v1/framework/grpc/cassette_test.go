@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func newCassetteHealthClient(t *testing.T, target string, interceptor grpc.UnaryClientInterceptor) healthpb.HealthClient {
+	t.Helper()
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(interceptor))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func Test_newCassetteUnaryInterceptorRecordAndReplay(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+	dir := t.TempDir()
+	addr, calls := startHealthServer(t)
+
+	record, err := newCassetteUnaryInterceptor(log, "test-client", config.CassetteClientConfig{Mode: config.CassetteModeRecord, Dir: dir})
+	require.NoError(t, err)
+
+	live := newCassetteHealthClient(t, addr, record)
+
+	resp, err := live.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	_, err = live.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.FileExists(t, filepath.Join(dir, "test-client.json"))
+
+	replay, err := newCassetteUnaryInterceptor(log, "test-client", config.CassetteClientConfig{Mode: config.CassetteModeReplay, Dir: dir})
+	require.NoError(t, err)
+
+	// nothing listens on the target, replayed calls never reach the network
+	offline := newCassetteHealthClient(t, "passthrough:///127.0.0.1:1", replay)
+
+	resp, err = offline.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	_, err = offline.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// recordings are served again once used
+	_, err = offline.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	_, err = offline.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "other"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_newCassetteUnaryInterceptorErrors(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+	dir := t.TempDir()
+
+	_, err := newCassetteUnaryInterceptor(log, "test-client", config.CassetteClientConfig{Mode: "rewind", Dir: dir})
+	assert.ErrorContains(t, err, "unsupported cassette mode")
+
+	_, err = newCassetteUnaryInterceptor(log, "missing-client", config.CassetteClientConfig{Mode: config.CassetteModeReplay, Dir: dir})
+	assert.ErrorContains(t, err, "error reading cassette")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken-client.json"), []byte("{"), 0o600))
+
+	_, err = newCassetteUnaryInterceptor(log, "broken-client", config.CassetteClientConfig{Mode: config.CassetteModeReplay, Dir: dir})
+	assert.ErrorContains(t, err, "error parsing cassette")
+}
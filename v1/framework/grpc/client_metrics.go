@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+const (
+	dataSourceAttr = "datasource"
+	codeAttr       = "code"
+	cbRejectedAttr = "cb_rejected"
+)
+
+// newMetricsUnaryInterceptor records the request count, the error count by grpc code and the latency of the calls of a
+// client, attributed to the client, the method and the datasource which made the call. It runs inside the metadata
+// propagation and fallback interceptors and outside all the others, so that the latency includes the time spent in the
+// bulkhead, retries and hedges, and calls rejected by an open circuit breaker are told apart from the errors of the
+// downstream. A call served with a last good response by the fallback interceptor is still counted as a failure.
+func newMetricsUnaryInterceptor(client string, clientMetrics *metrics.ClientMetrics) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		if clientMetrics == nil {
+			return err
+		}
+
+		paramsMap := map[string]string{
+			clientNameAttr: client,
+			methodAttr:     method,
+			dataSourceAttr: utils.DataSourceFromContext(ctx),
+			cbRejectedAttr: strconv.FormatBool(errors.Is(err, circuitbreaker.ErrOpen)),
+		}
+
+		metrics.AddCounter(clientMetrics.RequestCount, ctx, "GrpcClient", paramsMap)
+		metrics.HistogramRecord(clientMetrics.Latency, ctx, "GrpcClient", paramsMap, time.Since(start))
+
+		if err != nil {
+			paramsMap[codeAttr] = status.Code(err).String()
+			metrics.AddCounter(clientMetrics.ErrorCount, ctx, "GrpcClient", paramsMap)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sumByName returns the sum of the data points of the counter with the name, keyed by the value of the attribute
+func sumByName(rm metricdata.ResourceMetrics, name string, key attribute.Key) map[string]int64 {
+	sums := make(map[string]int64)
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				value, _ := dp.Attributes.Value(key)
+				sums[value.AsString()] += dp.Value
+			}
+		}
+	}
+
+	return sums
+}
+
+func Test_newMetricsUnaryInterceptor(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	clientMetrics, err := metrics.NewClientMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("client-test"))
+	require.NoError(t, err)
+
+	interceptor := newMetricsUnaryInterceptor("test-client", clientMetrics)
+	ctx := utils.WithDataSource(context.Background(), "page-ds")
+
+	for _, callErr := range []error{nil, status.Error(codes.NotFound, "not found"), circuitbreaker.ErrOpen} {
+		err := interceptor(ctx, "/user.User/GetUser", nil, nil, nil,
+			func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return callErr
+			})
+		assert.Equal(t, callErr, err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	assert.Equal(t, map[string]int64{"page-ds": 3}, sumByName(rm, "bff_service_grpc_client_request_count", dataSourceAttr))
+	assert.Equal(t, map[string]int64{"false": 2, "true": 1}, sumByName(rm, "bff_service_grpc_client_request_count", cbRejectedAttr))
+	assert.Equal(t, map[string]int64{"NotFound": 1, "Unknown": 1}, sumByName(rm, "bff_service_grpc_client_error_count", codeAttr))
+}
+
+func Test_newMetricsUnaryInterceptorWithoutMetrics(t *testing.T) {
+	interceptor := newMetricsUnaryInterceptor("test-client", nil)
+
+	err := interceptor(context.Background(), "/user.User/GetUser", nil, nil, nil,
+		func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return circuitbreaker.ErrOpen
+		})
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+}
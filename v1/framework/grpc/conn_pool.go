@@ -0,0 +1,222 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+var errConnPoolClosed = errors.New("grpc connection pool is closed")
+
+// connPool spreads the calls of a client over a fixed number of connections in round-robin, so that the calls are not
+// limited by the max concurrent streams of a single HTTP/2 connection. A connection which is idle for longer than the
+// idle timeout, or older than the max lifetime, is replaced when it is picked next, and the replaced connection is
+// closed once the calls in flight on it are done.
+type connPool struct {
+	mu          sync.Mutex
+	conns       []*pooledConn
+	next        int
+	closed      bool
+	dial        func() (*grpc.ClientConn, error)
+	idleTimeout time.Duration
+	maxLifetime time.Duration
+	log         logger.Logger
+	client      string
+}
+
+type pooledConn struct {
+	conn      *grpc.ClientConn
+	createdAt time.Time
+	lastUsed  time.Time
+	inFlight  int
+	retired   bool
+}
+
+func newConnPool(log logger.Logger, client string, size int, idleTimeout, maxLifetime time.Duration,
+	dial func() (*grpc.ClientConn, error)) (*connPool, error) {
+	p := &connPool{
+		conns:       make([]*pooledConn, 0, size),
+		dial:        dial,
+		idleTimeout: idleTimeout,
+		maxLifetime: maxLifetime,
+		log:         log,
+		client:      client,
+	}
+
+	for i := 0; i < size; i++ {
+		conn, err := dial()
+		if err != nil {
+			_ = p.Close()
+			return nil, err
+		}
+
+		now := time.Now()
+		p.conns = append(p.conns, &pooledConn{conn: conn, createdAt: now, lastUsed: now})
+	}
+
+	return p, nil
+}
+
+func (p *connPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	pc, err := p.pick()
+	if err != nil {
+		return err
+	}
+	defer p.release(pc)
+
+	return pc.conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (p *connPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	pc, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := pc.conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		p.release(pc)
+		return nil, err
+	}
+
+	return newPooledStream(ctx, stream, func() { p.release(pc) }), nil
+}
+
+// Close closes every connection of the pool once the calls in flight on it are done
+func (p *connPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for _, pc := range p.conns {
+		p.retire(pc)
+	}
+
+	return nil
+}
+
+// GetState returns the best connectivity state of the connections of the pool, the pool is ready while any of its
+// connections is ready
+func (p *connPool) GetState() connectivity.State {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best := connectivity.Shutdown
+	for _, pc := range p.conns {
+		if state := pc.conn.GetState(); connStateRank[state] < connStateRank[best] {
+			best = state
+		}
+	}
+
+	return best
+}
+
+//nolint:gochecknoglobals // ranking of the connectivity states, the lower the better
+var connStateRank = map[connectivity.State]int{
+	connectivity.Ready:            0,
+	connectivity.Idle:             1,
+	connectivity.Connecting:       2,
+	connectivity.TransientFailure: 3,
+	connectivity.Shutdown:         4,
+}
+
+// pick returns the next connection of the pool, replacing it first when it is expired
+func (p *connPool) pick() (*pooledConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, errConnPoolClosed
+	}
+
+	idx := p.next
+	p.next = (p.next + 1) % len(p.conns)
+
+	now := time.Now()
+	pc := p.conns[idx]
+
+	if p.expired(pc, now) {
+		conn, err := p.dial()
+		if err != nil {
+			// keep using the expired connection, it is replaced on one of the next picks
+			p.log.Errorf("error replacing expired grpc connection for client: %s, err: %v", p.client, err)
+		} else {
+			p.retire(pc)
+			pc = &pooledConn{conn: conn, createdAt: now}
+			p.conns[idx] = pc
+		}
+	}
+
+	pc.lastUsed = now
+	pc.inFlight++
+
+	return pc, nil
+}
+
+func (p *connPool) release(pc *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc.inFlight--
+	if pc.retired && pc.inFlight == 0 {
+		p.closeConn(pc)
+	}
+}
+
+func (p *connPool) expired(pc *pooledConn, now time.Time) bool {
+	if p.idleTimeout > 0 && now.Sub(pc.lastUsed) > p.idleTimeout {
+		return true
+	}
+
+	return p.maxLifetime > 0 && now.Sub(pc.createdAt) > p.maxLifetime
+}
+
+// retire marks the connection as replaced, it is closed right away when there are no calls in flight on it
+func (p *connPool) retire(pc *pooledConn) {
+	if pc.retired {
+		return
+	}
+
+	pc.retired = true
+	if pc.inFlight == 0 {
+		p.closeConn(pc)
+	}
+}
+
+func (p *connPool) closeConn(pc *pooledConn) {
+	if err := pc.conn.Close(); err != nil {
+		p.log.Errorf("error closing grpc connection for client: %s, err: %v", p.client, err)
+	}
+}
+
+// pooledStream releases its connection back to the pool once, when the stream is received to its end or fails, or when
+// the caller stops receiving before the end and its ctx is done, e.g. CollectStream with ErrStreamTooLong
+type pooledStream struct {
+	grpc.ClientStream
+	release func()
+}
+
+func newPooledStream(ctx context.Context, stream grpc.ClientStream, release func()) *pooledStream {
+	release = sync.OnceFunc(release)
+	stop := context.AfterFunc(ctx, release)
+
+	return &pooledStream{ClientStream: stream, release: func() {
+		stop()
+		release()
+	}}
+}
+
+func (s *pooledStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.release()
+	}
+
+	return err
+}
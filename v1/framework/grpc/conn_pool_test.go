@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func testDial() (*grpc.ClientConn, error) {
+	return grpc.NewClient("passthrough:///localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+func Test_connPoolRoundRobin(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+
+	pool, err := newConnPool(log, "test-client", 3, 0, 0, testDial)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var picked []*grpc.ClientConn
+	for i := 0; i < 6; i++ {
+		pc, err := pool.pick()
+		require.NoError(t, err)
+		pool.release(pc)
+		picked = append(picked, pc.conn)
+	}
+
+	assert.NotSame(t, picked[0], picked[1])
+	assert.NotSame(t, picked[1], picked[2])
+	assert.Equal(t, picked[:3], picked[3:])
+}
+
+func Test_connPoolReplacesExpiredConnection(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+
+	pool, err := newConnPool(log, "test-client", 1, 0, time.Millisecond, testDial)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	inFlight, err := pool.pick()
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	replaced, err := pool.pick()
+	require.NoError(t, err)
+	pool.release(replaced)
+
+	assert.NotSame(t, inFlight.conn, replaced.conn)
+	// the expired connection is only closed once its call in flight is done
+	assert.NotEqual(t, connectivity.Shutdown, inFlight.conn.GetState())
+
+	pool.release(inFlight)
+	assert.Equal(t, connectivity.Shutdown, inFlight.conn.GetState())
+}
+
+func Test_connPoolClose(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+
+	pool, err := newConnPool(log, "test-client", 2, 0, 0, testDial)
+	require.NoError(t, err)
+
+	assert.NoError(t, pool.Close())
+
+	_, err = pool.pick()
+	assert.ErrorIs(t, err, errConnPoolClosed)
+}
+
+func Test_pooledStreamReleasesConnection(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+
+	pool, err := newConnPool(log, "test-client", 1, 0, 0, testDial)
+	require.NoError(t, err)
+
+	// a stream received to its end releases its connection once
+	pc, err := pool.pick()
+	require.NoError(t, err)
+
+	stream := newPooledStream(context.Background(), &fakeClientStream{n: 1}, func() { pool.release(pc) })
+	require.NoError(t, drain(stream))
+	assert.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+	assert.Equal(t, 0, pc.inFlight)
+
+	// a stream the caller stops receiving releases its connection once its ctx is done
+	pc, err = pool.pick()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream = newPooledStream(ctx, &fakeClientStream{n: 5}, func() { pool.release(pc) })
+	require.NoError(t, stream.RecvMsg(nil))
+
+	require.NoError(t, pool.Close())
+	assert.NotEqual(t, connectivity.Shutdown, pc.conn.GetState(), "retired connection is kept while the stream is in flight")
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		return pc.conn.GetState() == connectivity.Shutdown
+	}, time.Second, time.Millisecond)
+}
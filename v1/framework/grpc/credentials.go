@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+)
+
+// getClientCredentials returns the transport credentials configured with `<client>.tls.*`, clients without a tls mode
+// keep the credentials derived from ENV and the endpoint
+func getClientCredentials(endpoint string, tlsConfig config.TLSClientConfig) (credentials.TransportCredentials, error) {
+	switch tlsConfig.Mode {
+	case "":
+		return getCredentials(endpoint), nil
+	case config.TLSModeInsecure:
+		return insecure.NewCredentials(), nil
+	case config.TLSModeTLS, config.TLSModeMTLS:
+	default:
+		return nil, fmt.Errorf("unsupported tls mode: %s", tlsConfig.Mode)
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: tlsConfig.ServerName,
+	}
+
+	if tlsConfig.CAFile != "" {
+		caPEM, err := os.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading tls ca file: %w", err)
+		}
+
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in tls ca file: %s", tlsConfig.CAFile)
+		}
+
+		tlsCfg.RootCAs = roots
+	}
+
+	if tlsConfig.Mode == config.TLSModeMTLS {
+		cert, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading tls client certificate: %w", err)
+		}
+
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// tlsFingerprint identifies the tls config of a client along with the modification time of its certificate files, so
+// that rotated certificates are reloaded from disk by rebuilding the connection
+func tlsFingerprint(tlsConfig config.TLSClientConfig) string {
+	parts := []string{tlsConfig.Mode, tlsConfig.ServerName}
+
+	for _, file := range []string{tlsConfig.CAFile, tlsConfig.CertFile, tlsConfig.KeyFile} {
+		modTime := ""
+		if info, err := os.Stat(file); err == nil {
+			modTime = strconv.FormatInt(info.ModTime().UnixNano(), 10)
+		}
+
+		parts = append(parts, file, modTime)
+	}
+
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self-signed certificate and its key to dir, returning their paths
+func writeTestCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func Test_getClientCredentials(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir)
+
+	badCAFile := filepath.Join(dir, "bad-ca.pem")
+	require.NoError(t, os.WriteFile(badCAFile, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name         string
+		endpoint     string
+		tlsConfig    config.TLSClientConfig
+		wantProtocol string
+		wantErr      bool
+	}{
+		{
+			name:         "no tls mode keeps the legacy credentials",
+			endpoint:     "localhost:8080",
+			wantProtocol: "insecure",
+		},
+		{
+			name:         "insecure",
+			endpoint:     "test-client.internal:443",
+			tlsConfig:    config.TLSClientConfig{Mode: config.TLSModeInsecure},
+			wantProtocol: "insecure",
+		},
+		{
+			name:         "tls with system roots",
+			tlsConfig:    config.TLSClientConfig{Mode: config.TLSModeTLS, ServerName: "test-client.internal"},
+			wantProtocol: "tls",
+		},
+		{
+			name:         "tls with custom ca",
+			tlsConfig:    config.TLSClientConfig{Mode: config.TLSModeTLS, CAFile: certFile},
+			wantProtocol: "tls",
+		},
+		{
+			name:         "mtls",
+			tlsConfig:    config.TLSClientConfig{Mode: config.TLSModeMTLS, CAFile: certFile, CertFile: certFile, KeyFile: keyFile},
+			wantProtocol: "tls",
+		},
+		{
+			name:      "invalid ca file",
+			tlsConfig: config.TLSClientConfig{Mode: config.TLSModeTLS, CAFile: badCAFile},
+			wantErr:   true,
+		},
+		{
+			name:      "missing ca file",
+			tlsConfig: config.TLSClientConfig{Mode: config.TLSModeTLS, CAFile: filepath.Join(dir, "missing.pem")},
+			wantErr:   true,
+		},
+		{
+			name:      "mtls without client certificate",
+			tlsConfig: config.TLSClientConfig{Mode: config.TLSModeMTLS},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown mode",
+			tlsConfig: config.TLSClientConfig{Mode: "plaintext"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getClientCredentials(tt.endpoint, tt.tlsConfig)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProtocol, got.Info().SecurityProtocol)
+		})
+	}
+}
+
+func Test_tlsFingerprint(t *testing.T) {
+	certFile, keyFile := writeTestCert(t, t.TempDir())
+	tlsConfig := config.TLSClientConfig{Mode: config.TLSModeMTLS, CertFile: certFile, KeyFile: keyFile}
+
+	before := tlsFingerprint(tlsConfig)
+	assert.Equal(t, before, tlsFingerprint(tlsConfig))
+
+	// a rotated certificate changes the fingerprint
+	rotatedAt := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, rotatedAt, rotatedAt))
+	assert.NotEqual(t, before, tlsFingerprint(tlsConfig))
+
+	assert.NotEqual(t, tlsFingerprint(config.TLSClientConfig{}), tlsFingerprint(config.TLSClientConfig{Mode: config.TLSModeTLS}))
+}
@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/pickfirst"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // registers the gzip compressor
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+)
+
+//nolint:gochecknoglobals // names of the grpc balancers of the lb policies of the client configs
+var lbPolicies = map[string]string{
+	config.LBPolicyPickFirst:    pickfirst.Name,
+	config.LBPolicyRoundRobin:   roundrobin.Name,
+	config.LBPolicyLeastRequest: leastrequest.Name,
+}
+
+// newClientDial returns the dial of the connections of a client, with the resolver, load balancing, keepalive, message
+// size and compression options of its dial config. It fails when the dial config names an unknown resolver, lb policy
+// or compressor.
+func newClientDial(endpoint string, dialConfig config.DialClientConfig, opts ...grpc.DialOption) (func() (*grpc.ClientConn, error), error) {
+	switch dialConfig.Resolver {
+	case "", config.ResolverDNS, config.ResolverPassthrough, config.ResolverStatic:
+	default:
+		return nil, fmt.Errorf("unsupported resolver: %s", dialConfig.Resolver)
+	}
+
+	switch {
+	case dialConfig.ServiceConfig != "":
+		opts = append(opts, grpc.WithDefaultServiceConfig(dialConfig.ServiceConfig))
+	case dialConfig.LBPolicy != "":
+		balancer, ok := lbPolicies[dialConfig.LBPolicy]
+		if !ok {
+			return nil, fmt.Errorf("unsupported lb policy: %s", dialConfig.LBPolicy)
+		}
+
+		opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingConfig": [{%q: {}}]}`, balancer)))
+	}
+
+	if dialConfig.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                dialConfig.KeepaliveTime,
+			Timeout:             dialConfig.KeepaliveTimeout,
+			PermitWithoutStream: dialConfig.KeepalivePermitWithoutStream,
+		}))
+	}
+
+	var callOpts []grpc.CallOption
+	if dialConfig.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(dialConfig.MaxRecvMsgSize))
+	}
+
+	if dialConfig.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(dialConfig.MaxSendMsgSize))
+	}
+
+	if dialConfig.Compression != "" {
+		if encoding.GetCompressor(dialConfig.Compression) == nil {
+			return nil, fmt.Errorf("unsupported compression: %s", dialConfig.Compression)
+		}
+
+		callOpts = append(callOpts, grpc.UseCompressor(dialConfig.Compression))
+	}
+
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	return func() (*grpc.ClientConn, error) {
+		target, dialOpts := dialTarget(endpoint, dialConfig.Resolver), opts
+
+		// the static resolver is bound to a single connection, so every connection of a pool gets its own
+		if dialConfig.Resolver == config.ResolverStatic {
+			static := manual.NewBuilderWithScheme(config.ResolverStatic)
+			static.InitialState(resolver.State{Addresses: staticAddresses(endpoint)})
+			dialOpts = append(dialOpts[:len(dialOpts):len(dialOpts)], grpc.WithResolvers(static))
+		}
+
+		return grpc.NewClient(target, dialOpts...)
+	}, nil
+}
+
+// dialTarget returns the target of the endpoint for the resolver, endpoints which name a scheme are kept as they are
+func dialTarget(endpoint, scheme string) string {
+	if scheme == config.ResolverStatic {
+		return scheme + ":///static"
+	}
+
+	if scheme == "" || strings.Contains(endpoint, "://") {
+		return endpoint
+	}
+
+	return scheme + ":///" + endpoint
+}
+
+// staticAddresses returns the comma separated addresses of the endpoint of a client with the static resolver
+func staticAddresses(endpoint string) []resolver.Address {
+	var addresses []resolver.Address
+
+	for _, addr := range strings.Split(endpoint, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addresses = append(addresses, resolver.Address{Addr: addr})
+		}
+	}
+
+	return addresses
+}
@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startHealthServer starts a grpc server serving the health protocol on a local port, counting the calls it receives
+func startHealthServer(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	calls := &atomic.Int32{}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		calls.Add(1)
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), calls
+}
+
+func Test_newClientDial(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialConfig config.DialClientConfig
+		wantTarget string
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			wantTarget: "test-client:443",
+		},
+		{
+			name:       "dns resolver with lb policy",
+			dialConfig: config.DialClientConfig{Resolver: config.ResolverDNS, LBPolicy: config.LBPolicyRoundRobin},
+			wantTarget: "dns:///test-client:443",
+		},
+		{
+			name: "passthrough resolver with keepalive, message sizes and compression",
+			dialConfig: config.DialClientConfig{
+				Resolver:       config.ResolverPassthrough,
+				LBPolicy:       config.LBPolicyLeastRequest,
+				KeepaliveTime:  time.Minute,
+				MaxRecvMsgSize: 1 << 20,
+				MaxSendMsgSize: 1 << 20,
+				Compression:    "gzip",
+			},
+			wantTarget: "passthrough:///test-client:443",
+		},
+		{
+			name:       "static resolver",
+			dialConfig: config.DialClientConfig{Resolver: config.ResolverStatic},
+			wantTarget: "static:///static",
+		},
+		{
+			name:       "raw service config",
+			dialConfig: config.DialClientConfig{ServiceConfig: `{"loadBalancingConfig": [{"round_robin": {}}]}`},
+			wantTarget: "test-client:443",
+		},
+		{
+			name:       "invalid service config",
+			dialConfig: config.DialClientConfig{ServiceConfig: "invalid"},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown resolver",
+			dialConfig: config.DialClientConfig{Resolver: "consul"},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown lb policy",
+			dialConfig: config.DialClientConfig{LBPolicy: "random"},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown compression",
+			dialConfig: config.DialClientConfig{Compression: "zstd"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dial, err := newClientDial("test-client:443", tt.dialConfig, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err == nil {
+				var conn *grpc.ClientConn
+				if conn, err = dial(); err == nil {
+					defer conn.Close()
+					assert.Equal(t, tt.wantTarget, conn.Target())
+				}
+			}
+
+			assert.Equal(t, tt.wantErr, err != nil, "err: %v", err)
+		})
+	}
+}
+
+func Test_newClientDialStaticRoundRobin(t *testing.T) {
+	addr1, calls1 := startHealthServer(t)
+	addr2, calls2 := startHealthServer(t)
+
+	dial, err := newClientDial(strings.Join([]string{addr1, addr2}, ", "),
+		config.DialClientConfig{Resolver: config.ResolverStatic, LBPolicy: config.LBPolicyRoundRobin},
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	conn, err := dial()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// round robin only picks the ready backends, so the first calls may all go to the one which connected first
+	client := healthpb.NewHealthClient(conn)
+	for calls1.Load() == 0 || calls2.Load() == 0 {
+		_, err = client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+		require.NoError(t, err)
+	}
+
+	assert.Positive(t, calls1.Load())
+	assert.Positive(t, calls2.Load())
+}
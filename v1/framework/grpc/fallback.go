@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
+)
+
+type staleCtxKey struct{}
+
+// staleMarker records whether a call was served with a last good response, the marker of a datasource called by
+// another one marks its caller as well
+type staleMarker struct {
+	stale  atomic.Bool
+	parent *staleMarker
+}
+
+// WithStaleMarker returns a ctx which records whether a call made with it, or with a ctx derived from it, was served
+// with the last good response of its method instead of a response of the downstream. A marker added to a ctx which
+// already has one is only set by the calls made with it, and sets the outer marker as well.
+func WithStaleMarker(ctx context.Context) context.Context {
+	parent, _ := ctx.Value(staleCtxKey{}).(*staleMarker)
+	return context.WithValue(ctx, staleCtxKey{}, &staleMarker{parent: parent})
+}
+
+// IsStale reports whether a call made with the ctx was served with a last good response, see WithStaleMarker
+func IsStale(ctx context.Context) bool {
+	marker, _ := ctx.Value(staleCtxKey{}).(*staleMarker)
+	return marker != nil && marker.stale.Load()
+}
+
+func markStale(ctx context.Context) {
+	for marker, _ := ctx.Value(staleCtxKey{}).(*staleMarker); marker != nil; marker = marker.parent {
+		marker.stale.Store(true)
+	}
+}
+
+type fallbackEntry struct {
+	key      string
+	reply    []byte
+	storedAt time.Time
+}
+
+// fallbackCache keeps the last good response of every request, keyed by method and serialized request, and evicts the
+// least recently used responses beyond maxEntries
+type fallbackCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	maxEntries int
+	maxAge     time.Duration
+	now        func() time.Time
+}
+
+func newFallbackCache(maxEntries int, maxAge time.Duration) *fallbackCache {
+	return &fallbackCache{entries: map[string]*list.Element{}, order: list.New(), maxEntries: maxEntries, maxAge: maxAge, now: time.Now}
+}
+
+func (f *fallbackCache) put(key string, reply []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if elem, ok := f.entries[key]; ok {
+		entry := elem.Value.(*fallbackEntry)
+		entry.reply, entry.storedAt = reply, f.now()
+		f.order.MoveToFront(elem)
+
+		return
+	}
+
+	f.entries[key] = f.order.PushFront(&fallbackEntry{key: key, reply: reply, storedAt: f.now()})
+
+	for f.order.Len() > f.maxEntries {
+		oldest := f.order.Back()
+		f.order.Remove(oldest)
+		delete(f.entries, oldest.Value.(*fallbackEntry).key)
+	}
+}
+
+// get returns the last good response of the key, unless it is older than maxAge
+func (f *fallbackCache) get(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	elem, ok := f.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*fallbackEntry)
+	if f.maxAge > 0 && f.now().Sub(entry.storedAt) > f.maxAge {
+		f.order.Remove(elem)
+		delete(f.entries, key)
+
+		return nil, false
+	}
+
+	f.order.MoveToFront(elem)
+
+	return entry.reply, true
+}
+
+// isFallbackError reports whether a failed call may be served with a last good response: it was rejected by the
+// circuit breaker of its method, or the downstream is unavailable. Calls rejected by the bulkhead of the client are
+// not, the downstream is healthy.
+func isFallbackError(err error) bool {
+	if errors.Is(err, ErrBulkheadFull) {
+		return false
+	}
+
+	return errors.Is(err, circuitbreaker.ErrOpen) || status.Code(err) == codes.Unavailable
+}
+
+// callerIdentity returns the identity of the caller forwarded with the call, the user id or else a hash of the
+// authorization header, so that downstreams which derive the user from the forwarded metadata rather than from the
+// request never get the last good response of another user
+func callerIdentity(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	if userID := md.Get(propagation.UserIDHeader); len(userID) > 0 && userID[0] != "" {
+		return "user:" + userID[0]
+	}
+
+	if auth := md.Get(echo.HeaderAuthorization); len(auth) > 0 && auth[0] != "" {
+		hash := sha256.Sum256([]byte(auth[0]))
+		return "auth:" + hex.EncodeToString(hash[:])
+	}
+
+	return ""
+}
+
+// newFallbackUnaryInterceptor keeps the last good response of the configured read methods of a client, and serves it
+// when a call is rejected by the circuit breaker or the downstream is unavailable. Responses are kept per caller, see
+// callerIdentity. The calls it serves are marked stale in their ctx, see WithStaleMarker. It runs outside the metrics
+// interceptor, so that the failures of the downstream are still recorded.
+func newFallbackUnaryInterceptor(client string, fallbackConfig config.FallbackClientConfig,
+	fallbackMetrics *metrics.FallbackMetrics) grpc.UnaryClientInterceptor {
+	var servedCount, missCount metric.Int64Counter
+	if fallbackMetrics != nil {
+		servedCount = fallbackMetrics.ServedCount
+		missCount = fallbackMetrics.MissCount
+	}
+
+	allowlist := make(map[string]struct{}, len(fallbackConfig.Methods))
+	for _, method := range fallbackConfig.Methods {
+		allowlist[method] = struct{}{}
+	}
+
+	cache := newFallbackCache(fallbackConfig.MaxEntries, fallbackConfig.MaxAge)
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		reqMsg, isReqMsg := req.(proto.Message)
+		replyMsg, isReplyMsg := reply.(proto.Message)
+
+		if _, ok := allowlist[method]; !ok || !isReqMsg || !isReplyMsg {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		reqBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(reqMsg)
+		if err != nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		key := method + "|" + callerIdentity(ctx) + "|" + string(reqBytes)
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			if replyBytes, err := proto.Marshal(replyMsg); err == nil {
+				cache.put(key, replyBytes)
+			}
+
+			return nil
+		}
+
+		if !isFallbackError(err) {
+			return err
+		}
+
+		paramsMap := map[string]string{
+			clientNameAttr: client,
+			methodAttr:     method,
+		}
+
+		replyBytes, ok := cache.get(key)
+		if !ok || proto.Unmarshal(replyBytes, replyMsg) != nil {
+			if missCount != nil {
+				metrics.AddCounter(missCount, ctx, "Fallback", paramsMap)
+			}
+
+			return err
+		}
+
+		if servedCount != nil {
+			metrics.AddCounter(servedCount, ctx, "Fallback", paramsMap)
+		}
+
+		markStale(ctx)
+
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
+)
+
+func fakeHealthInvoker(servingStatus healthpb.HealthCheckResponse_ServingStatus, err error) grpc.UnaryInvoker {
+	return func(_ context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		if err != nil {
+			return err
+		}
+
+		reply.(*healthpb.HealthCheckResponse).Status = servingStatus
+
+		return nil
+	}
+}
+
+func Test_newFallbackUnaryInterceptor(t *testing.T) {
+	const method = "/grpc.health.v1.Health/Check"
+
+	interceptor := newFallbackUnaryInterceptor("test-client", config.FallbackClientConfig{Methods: []string{method}, MaxEntries: 10}, nil)
+	call := func(ctx context.Context, service, callMethod string, invoker grpc.UnaryInvoker) (*healthpb.HealthCheckResponse, error) {
+		reply := &healthpb.HealthCheckResponse{}
+		err := interceptor(ctx, callMethod, &healthpb.HealthCheckRequest{Service: service}, reply, nil, invoker)
+
+		return reply, err
+	}
+
+	reply, err := call(context.Background(), "page", method, fakeHealthInvoker(healthpb.HealthCheckResponse_SERVING, nil))
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, reply.Status)
+
+	for _, callErr := range []error{circuitbreaker.ErrOpen, status.Error(codes.Unavailable, "down")} {
+		ctx := WithStaleMarker(context.Background())
+		reply, err = call(ctx, "page", method, fakeHealthInvoker(0, callErr))
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, reply.Status, "last good response is served")
+		assert.True(t, IsStale(ctx))
+	}
+
+	ctx := WithStaleMarker(context.Background())
+	_, err = call(ctx, "page", method, fakeHealthInvoker(0, status.Error(codes.NotFound, "not found")))
+	assert.Equal(t, codes.NotFound, status.Code(err), "only unavailable downstreams are served")
+
+	_, err = call(ctx, "page", method, fakeHealthInvoker(0, ErrBulkheadFull))
+	assert.ErrorIs(t, err, ErrBulkheadFull, "calls rejected by the bulkhead are not served")
+
+	_, err = call(ctx, "user", method, fakeHealthInvoker(0, circuitbreaker.ErrOpen))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen, "requests are cached by their content")
+
+	otherUser := metadata.AppendToOutgoingContext(ctx, propagation.UserIDHeader, "user-2")
+	_, err = call(otherUser, "page", method, fakeHealthInvoker(0, circuitbreaker.ErrOpen))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen, "responses are cached per caller")
+
+	_, err = call(ctx, "page", "/grpc.health.v1.Health/List", fakeHealthInvoker(0, circuitbreaker.ErrOpen))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen, "methods which are not configured are not cached")
+	assert.False(t, IsStale(ctx))
+}
+
+func Test_staleMarkerPerDataSource(t *testing.T) {
+	const method = "/grpc.health.v1.Health/Check"
+
+	interceptor := newFallbackUnaryInterceptor("test-client", config.FallbackClientConfig{Methods: []string{method}, MaxEntries: 10}, nil)
+	call := func(ctx context.Context, service string, invoker grpc.UnaryInvoker) error {
+		return interceptor(ctx, method, &healthpb.HealthCheckRequest{Service: service}, &healthpb.HealthCheckResponse{}, nil, invoker)
+	}
+
+	require.NoError(t, call(context.Background(), "page", fakeHealthInvoker(healthpb.HealthCheckResponse_SERVING, nil)))
+
+	// the widget datasources of a page share its request ctx, each with a marker of its own
+	page := WithStaleMarker(context.Background())
+	widgetA, widgetB := WithStaleMarker(page), WithStaleMarker(page)
+
+	require.NoError(t, call(widgetA, "page", fakeHealthInvoker(0, circuitbreaker.ErrOpen)))
+	require.NoError(t, call(widgetB, "user", fakeHealthInvoker(healthpb.HealthCheckResponse_SERVING, nil)))
+
+	assert.True(t, IsStale(widgetA), "the datasource served with the fallback is stale")
+	assert.False(t, IsStale(widgetB), "its sibling is not")
+	assert.True(t, IsStale(page), "the page which calls it is stale")
+}
+
+func Test_callerIdentity(t *testing.T) {
+	assert.Empty(t, callerIdentity(context.Background()))
+
+	byUser := metadata.AppendToOutgoingContext(context.Background(), propagation.UserIDHeader, "user-1", "Authorization", "Bearer a")
+	assert.Equal(t, "user:user-1", callerIdentity(byUser))
+
+	byToken := metadata.AppendToOutgoingContext(context.Background(), "Authorization", "Bearer a")
+	otherToken := metadata.AppendToOutgoingContext(context.Background(), "Authorization", "Bearer b")
+	assert.NotEqual(t, callerIdentity(byToken), callerIdentity(otherToken))
+	assert.NotContains(t, callerIdentity(byToken), "Bearer", "tokens are not kept in the cache keys")
+}
+
+func Test_fallbackCache(t *testing.T) {
+	now := time.Now()
+	cache := newFallbackCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.put("a", []byte("a"))
+	cache.put("b", []byte("b"))
+
+	// reading a makes b the least recently used response
+	_, ok := cache.get("a")
+	assert.True(t, ok)
+
+	cache.put("c", []byte("c"))
+
+	_, ok = cache.get("b")
+	assert.False(t, ok, "least recently used response is evicted")
+
+	got, ok := cache.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("c"), got)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.get("a")
+	assert.False(t, ok, "responses older than max age are not served")
+}
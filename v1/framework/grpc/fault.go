@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/faults"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+// newFaultUnaryInterceptor injects the latency and errors of the matching fault injection rules into the calls of the
+// client. It is the innermost interceptor of the client, so that the circuit breaker, retries and hedging see the
+// injected faults like faults of the downstream.
+func newFaultUnaryInterceptor(log logger.Logger, client string, injector *faults.Injector) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		rule, inject := injector.MatchCall(client, method, utils.DataSourceFromContext(ctx))
+		if !inject {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		log.Infof("injecting fault into call of client: %s, method: %s, rule: %+v", client, method, rule)
+
+		if err := faults.Sleep(ctx, rule); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		if rule.Code != codes.OK {
+			return status.Errorf(rule.Code, "fault injected into call of client: %s", client)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
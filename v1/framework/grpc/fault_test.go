@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/faults"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_newFaultUnaryInterceptor(t *testing.T) {
+	t.Setenv(utils.Env, utils.EnvStage)
+
+	rules := `[
+		{"client": "test-client", "method": "/user.User/GetUser", "percentage": 100, "code": "UNAVAILABLE"},
+		{"client": "test-client", "method": "/user.User/ListUsers", "percentage": 100, "delay_ms": 50}
+	]`
+
+	tests := []struct {
+		name      string
+		method    string
+		timeout   time.Duration
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{
+			name:     "call is aborted with the code of the rule",
+			method:   "/user.User/GetUser",
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:      "call is delayed",
+			method:    "/user.User/ListUsers",
+			timeout:   time.Second,
+			wantCalls: 1,
+		},
+		{
+			name:     "delay is cut by the deadline of the call",
+			method:   "/user.User/ListUsers",
+			timeout:  10 * time.Millisecond,
+			wantCode: codes.DeadlineExceeded,
+		},
+		{
+			name:      "call without rule",
+			method:    "/user.User/UpdateUser",
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, _, log, _, _, _ := getTestingParams(t)
+			dc := config.NewMockDynamicConfig(ctrl)
+			dc.EXPECT().Get(faults.RulesKey).Return(rules, nil)
+
+			interceptor := newFaultUnaryInterceptor(log, "test-client", faults.NewInjector(&config.Config{DynamicConfig: dc}, log))
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			calls := 0
+			err := interceptor(ctx, tt.method, nil, nil, nil,
+				func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+					calls++
+					return nil
+				})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
 )
 
 type Handler struct {
@@ -17,10 +18,23 @@ type Handler struct {
 	logger              logger.Logger
 	meter               metric.Meter
 	safeGrpcConnections ISafeGrpcConnections
+	reloader            connReloader
+	connMetrics         *metrics.ConnMetrics
 }
 
 func NewGRPC(l logger.Logger, meter metric.Meter) *Handler {
-	return &Handler{logger: l, meter: meter, safeGrpcConnections: &SafeGrpcConnections{clientConnections: make(map[string]*grpc.ClientConn)}}
+	handler := &Handler{logger: l, meter: meter, safeGrpcConnections: &SafeGrpcConnections{clientConnections: make(map[string]grpc.ClientConnInterface)}}
+
+	if meter != nil {
+		connMetrics, err := metrics.NewConnMetrics(meter)
+		if err != nil {
+			l.Errorf("error creating grpc connection metrics: %v", err)
+		}
+
+		handler.connMetrics = connMetrics
+	}
+
+	return handler
 }
 
 //nolint:gochecknoglobals // cannot be changed further
@@ -29,29 +43,34 @@ var (
 	globalMutex sync.Mutex
 )
 
-// GetConn returns the grpc connection if already present for a particular client or will initialize a new connection
+// GetConn returns the grpc connection if already present for a particular client or will initialize a new connection,
+// the connection is rebuilt when the endpoint of the client changes in dynamic config
 func (handler *Handler) GetConn(ctx echo.Context, log logger.Logger, client string, cnf *config.Config) (grpc.ClientConnInterface, error) {
 	var (
 		err  error
-		conn *grpc.ClientConn
+		conn grpc.ClientConnInterface
 	)
 
 	// Get or create the pool for the specified client
 	conn, exists := handler.safeGrpcConnections.GetConnectionForClient(client)
 	if exists {
 		log.WithContext(ctx).Infof("connection already exists for client: %s", client)
-		return conn, nil
+		return handler.reloadConnIfChanged(ctx, log, client, cnf, conn), nil
 	}
 
 	mutex := getOrCreateMutex(client)
 	mutex.Lock()
 	defer mutex.Unlock()
 
+	fingerprint := connFingerprint(client, cnf)
+
 	conn, err = createClientConnection(ctx, log, client, cnf, handler)
 	if err != nil {
 		log.WithContext(ctx).Errorf("error while creating connection for client:%v, err: %v", client, err)
 		return nil, err
 	}
+
+	handler.reloader.track(client, fingerprint)
 	log.WithContext(ctx).Infof("successfully created connection for client: %s", client)
 
 	return conn, nil
@@ -70,7 +89,7 @@ func getOrCreateMutex(client string) *sync.Mutex {
 	return poolMutexes[client]
 }
 
-func createClientConnection(ctx echo.Context, log logger.Logger, client string, cnf *config.Config, handler *Handler) (*grpc.ClientConn, error) {
+func createClientConnection(ctx echo.Context, log logger.Logger, client string, cnf *config.Config, handler *Handler) (grpc.ClientConnInterface, error) {
 	conn, err := handler.safeGrpcConnections.CreateConnectionForClient(ctx, log, client, cnf, handler.meter)
 	if err != nil {
 		return nil, err
@@ -80,3 +99,16 @@ func createClientConnection(ctx echo.Context, log logger.Logger, client string,
 
 	return conn, nil
 }
+
+// Close closes the connection of every client, it is meant to be called on shutdown once the in-flight requests are
+// done. The connections replaced by a reload are closed after their drain period on their own.
+func (handler *Handler) Close() error {
+	if err := handler.safeGrpcConnections.Close(); err != nil {
+		handler.logger.Errorf("error closing grpc connections, err: %v", err)
+		return err
+	}
+
+	handler.logger.Infof("closed grpc connections")
+
+	return nil
+}
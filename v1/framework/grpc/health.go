@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthProbeTimeout is the timeout of the gRPC health protocol probes of all the downstreams, which are probed
+// concurrently, a deadline of the request which is earlier applies instead
+const HealthProbeTimeout = time.Second
+
+type healthProbeCtxKey struct{}
+
+// ConnHealth is the health of the connection of a downstream client
+type ConnHealth struct {
+	Client  string
+	State   string
+	Healthy bool
+	Error   string
+}
+
+// CheckConnections reports the connectivity state of every client connection, a connection in TRANSIENT_FAILURE or
+// SHUTDOWN is unhealthy. With probe, the downstreams are also checked with the gRPC health protocol, downstreams which
+// do not implement it are only checked by their connectivity state.
+func (handler *Handler) CheckConnections(ctx context.Context, probe bool) []ConnHealth {
+	conns := handler.safeGrpcConnections.GetConnections()
+
+	if probe {
+		probeCtx, cancel := context.WithTimeout(context.WithValue(ctx, healthProbeCtxKey{}, true), HealthProbeTimeout)
+		defer cancel()
+
+		ctx = probeCtx
+	}
+
+	var wg sync.WaitGroup
+
+	report := make([]ConnHealth, len(conns))
+	i := 0
+
+	for client, conn := range conns {
+		wg.Add(1)
+
+		go func(i int, client string, conn grpc.ClientConnInterface) {
+			defer wg.Done()
+
+			report[i] = checkConn(ctx, client, conn, probe)
+		}(i, client, conn)
+
+		i++
+	}
+
+	wg.Wait()
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Client < report[j].Client
+	})
+
+	return report
+}
+
+func checkConn(ctx context.Context, client string, conn grpc.ClientConnInterface, probe bool) ConnHealth {
+	health := ConnHealth{Client: client, Healthy: true}
+
+	if stater, ok := conn.(interface{ GetState() connectivity.State }); ok {
+		state := stater.GetState()
+		health.State = state.String()
+
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			health.Healthy = false
+			health.Error = "connection is in " + health.State
+
+			return health
+		}
+	}
+
+	if !probe {
+		return health
+	}
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+	case err != nil:
+		health.Healthy = false
+		health.Error = err.Error()
+	case resp.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+		health.Healthy = false
+		health.Error = "health probe returned " + resp.GetStatus().String()
+	}
+
+	return health
+}
+
+// skipOnHealthProbe runs the interceptor for every call but the health probes of CheckConnections, so that a probe
+// reaches the downstream without going through the breakers, limits, metrics and test doubles of the client
+func skipOnHealthProbe(interceptor grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if probe, _ := ctx.Value(healthProbeCtxKey{}).(bool); probe && method == healthpb.Health_Check_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		return interceptor(ctx, method, req, reply, cc, invoker, opts...)
+	}
+}
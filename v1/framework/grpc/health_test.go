@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_CheckConnections(t *testing.T) {
+	idleConn, err := testDial()
+	assert.NoError(t, err)
+	defer idleConn.Close()
+
+	closedConn, err := testDial()
+	assert.NoError(t, err)
+	closedConn.Close()
+
+	handler := &Handler{safeGrpcConnections: &SafeGrpcConnections{clientConnections: map[string]grpc.ClientConnInterface{
+		"user-client": idleConn,
+		"page-client": closedConn,
+	}}}
+
+	report := handler.CheckConnections(context.Background(), false)
+
+	assert.Equal(t, []ConnHealth{
+		{Client: "page-client", State: "SHUTDOWN", Healthy: false, Error: "connection is in SHUTDOWN"},
+		{Client: "user-client", State: "IDLE", Healthy: true},
+	}, report)
+}
+
+func TestHandler_CheckConnectionsProbeSkipsInterceptors(t *testing.T) {
+	addr, _ := startHealthServer(t)
+
+	intercepted := &atomic.Int32{}
+	rejectAll := func(context.Context, string, any, any, *grpc.ClientConn, grpc.UnaryInvoker, ...grpc.CallOption) error {
+		intercepted.Add(1)
+		return status.Error(codes.Unavailable, "rejected by the interceptor")
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(skipOnHealthProbe(rejectAll)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	handler := &Handler{safeGrpcConnections: &SafeGrpcConnections{clientConnections: map[string]grpc.ClientConnInterface{
+		"page-client": conn,
+	}}}
+
+	report := handler.CheckConnections(context.Background(), true)
+	require.Len(t, report, 1)
+	assert.True(t, report[0].Healthy, report[0].Error)
+	assert.Zero(t, intercepted.Load(), "the probe skips the interceptors of the client")
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "other calls of the health method go through them")
+	assert.Equal(t, int32(1), intercepted.Load())
+}
+
+func TestHandler_CheckConnectionsProbesConcurrently(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	slow := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		time.Sleep(HealthProbeTimeout / 2)
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(slow, health.NewServer())
+
+	go func() { _ = slow.Serve(lis) }()
+	t.Cleanup(slow.Stop)
+
+	conns := map[string]grpc.ClientConnInterface{}
+
+	for _, client := range []string{"page-client", "user-client", "cal-client"} {
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		conns[client] = conn
+	}
+
+	handler := &Handler{safeGrpcConnections: &SafeGrpcConnections{clientConnections: conns}}
+
+	start := time.Now()
+	report := handler.CheckConnections(context.Background(), true)
+
+	assert.Less(t, time.Since(start), HealthProbeTimeout, "the downstreams are probed concurrently")
+	require.Len(t, report, 3)
+
+	for _, conn := range report {
+		assert.True(t, conn.Healthy, conn.Error)
+	}
+}
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+)
+
+// hedgeBudgetMaxTokens is the max number of hedges a client may send in a burst
+const hedgeBudgetMaxTokens = 10
+
+// hedgeBudget caps the hedged calls of a client to a ratio of its calls: every call adds ratio tokens, and every hedge
+// takes one token
+type hedgeBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+}
+
+func newHedgeBudget(ratio float64) *hedgeBudget {
+	return &hedgeBudget{ratio: ratio}
+}
+
+func (b *hedgeBudget) onCall() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(hedgeBudgetMaxTokens, b.tokens+b.ratio)
+}
+
+func (b *hedgeBudget) tryAcquire() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+type hedgeResult struct {
+	reply proto.Message
+	err   error
+	hedge bool
+}
+
+// newHedgingUnaryInterceptor sends a second identical call for the allowlisted methods when the first one is not done
+// after the hedge delay, and uses whichever call answers first. Each call gets its own reply, the reply of the winning
+// call is copied into the reply of the caller and the other call is canceled.
+func newHedgingUnaryInterceptor(client string, hedgeConfig config.HedgeClientConfig, budget *hedgeBudget,
+	hedgeMetrics *metrics.HedgeMetrics) grpc.UnaryClientInterceptor {
+	allowlist := make(map[string]struct{}, len(hedgeConfig.Methods))
+	for _, method := range hedgeConfig.Methods {
+		allowlist[method] = struct{}{}
+	}
+
+	var hedgeCount, winCount, budgetExhaustedCount metric.Int64Counter
+	if hedgeMetrics != nil {
+		hedgeCount = hedgeMetrics.HedgeCount
+		winCount = hedgeMetrics.WinCount
+		budgetExhaustedCount = hedgeMetrics.BudgetExhaustedCount
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		msg, ok := reply.(proto.Message)
+		if _, hedged := allowlist[method]; !hedged || !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		budget.onCall()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan hedgeResult, 2)
+		call := func(hedge bool) {
+			r := msg.ProtoReflect().New().Interface()
+			results <- hedgeResult{reply: r, err: invoker(ctx, method, req, r, cc, opts...), hedge: hedge}
+		}
+
+		go call(false)
+
+		timer := time.NewTimer(hedgeConfig.Delay)
+		defer timer.Stop()
+
+		select {
+		case res := <-results:
+			return useHedgeResult(msg, res)
+		case <-timer.C:
+		}
+
+		calls := 1
+		if budget.tryAcquire() {
+			addHedgeCounter(ctx, hedgeCount, client, method)
+
+			go call(true)
+			calls++
+		} else {
+			addHedgeCounter(ctx, budgetExhaustedCount, client, method)
+		}
+
+		// the first successful answer is used, the last failure is returned if every call failed
+		var res hedgeResult
+		for i := 0; i < calls; i++ {
+			if res = <-results; res.err == nil {
+				break
+			}
+		}
+
+		if res.hedge && res.err == nil {
+			addHedgeCounter(ctx, winCount, client, method)
+		}
+
+		return useHedgeResult(msg, res)
+	}
+}
+
+func useHedgeResult(reply proto.Message, res hedgeResult) error {
+	if res.err != nil {
+		return res.err
+	}
+
+	proto.Reset(reply)
+	proto.Merge(reply, res.reply)
+
+	return nil
+}
+
+func addHedgeCounter(ctx context.Context, counter metric.Int64Counter, client, method string) {
+	if counter == nil {
+		return
+	}
+
+	paramsMap := map[string]string{
+		clientNameAttr: client,
+		methodAttr:     method,
+	}
+	metrics.AddCounter(counter, ctx, "Hedge", paramsMap)
+}
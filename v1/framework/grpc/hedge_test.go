@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// slowFirstInvoker answers the first call after the delay and every other call right away
+func slowFirstInvoker(delay time.Duration, calls *atomic.Int32) grpc.UnaryInvoker {
+	return func(ctx context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		answer := "hedge"
+		if calls.Add(1) == 1 {
+			answer = "original"
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		reply.(*wrapperspb.StringValue).Value = answer
+		return nil
+	}
+}
+
+func Test_newHedgingUnaryInterceptor(t *testing.T) {
+	hedgeMethod := "/user.User/GetUser"
+
+	tests := []struct {
+		name       string
+		method     string
+		firstDelay time.Duration
+		budget     *hedgeBudget
+		wantCalls  int32
+		wantReply  string
+	}{
+		{
+			name:       "hedge answers before slow call",
+			method:     hedgeMethod,
+			firstDelay: time.Second,
+			budget:     &hedgeBudget{tokens: 1, ratio: 0.1},
+			wantCalls:  2,
+			wantReply:  "hedge",
+		},
+		{
+			name:       "fast call is not hedged",
+			method:     hedgeMethod,
+			firstDelay: 0,
+			budget:     &hedgeBudget{tokens: 1, ratio: 0.1},
+			wantCalls:  1,
+			wantReply:  "original",
+		},
+		{
+			name:       "method not in allowlist is not hedged",
+			method:     "/user.User/UpdateUser",
+			firstDelay: 50 * time.Millisecond,
+			budget:     &hedgeBudget{tokens: 1, ratio: 0.1},
+			wantCalls:  1,
+			wantReply:  "original",
+		},
+		{
+			name:       "no hedge when budget is exhausted",
+			method:     hedgeMethod,
+			firstDelay: 50 * time.Millisecond,
+			budget:     newHedgeBudget(0.1),
+			wantCalls:  1,
+			wantReply:  "original",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hedgeConfig := config.HedgeClientConfig{Delay: 10 * time.Millisecond, Methods: []string{hedgeMethod}}
+			interceptor := newHedgingUnaryInterceptor("test-client", hedgeConfig, tt.budget, nil)
+
+			var calls atomic.Int32
+			reply := &wrapperspb.StringValue{}
+			err := interceptor(context.Background(), tt.method, nil, reply, nil, slowFirstInvoker(tt.firstDelay, &calls))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			assert.Equal(t, tt.wantReply, reply.GetValue())
+		})
+	}
+}
+
+func Test_hedgeBudget(t *testing.T) {
+	budget := newHedgeBudget(0.5)
+	assert.False(t, budget.tryAcquire())
+
+	budget.onCall()
+	budget.onCall()
+	assert.True(t, budget.tryAcquire())
+	assert.False(t, budget.tryAcquire())
+
+	for i := 0; i < 100; i++ {
+		budget.onCall()
+	}
+	assert.Equal(t, float64(hedgeBudgetMaxTokens), budget.tokens)
+}
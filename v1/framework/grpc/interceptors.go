@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"google.golang.org/grpc"
+)
+
+const (
+	clientNameAttr = "client-name"
+	methodAttr     = "method"
+)
+
+type methodCtxKey struct{}
+
+// methodFromContext returns the grpc method set by the client interceptors, used as metric attribute by policy listeners
+func methodFromContext(ctx context.Context) string {
+	method, _ := ctx.Value(methodCtxKey{}).(string)
+	return method
+}
+
+// methodExecutors lazily creates a failsafe executor for every method of a client, each with its own circuit breaker,
+// so that one failing method does not open the circuit for the other methods of the client
+type methodExecutors struct {
+	mu           sync.RWMutex
+	executors    map[string]failsafe.Executor[any]
+	breakers     map[string]circuitbreaker.CircuitBreaker[any]
+	newBreaker   func(method string) circuitbreaker.CircuitBreaker[any]
+	retry        retrypolicy.RetryPolicy[any]
+	retryMethods map[string]struct{}
+	// forcedOpen reports whether the breaker of the method is forced open by an operator, nil when never forced
+	forcedOpen func(method string) bool
+}
+
+func newMethodExecutors(newBreaker func(method string) circuitbreaker.CircuitBreaker[any], retry retrypolicy.RetryPolicy[any],
+	retryMethods []string) *methodExecutors {
+	allowlist := make(map[string]struct{}, len(retryMethods))
+	for _, method := range retryMethods {
+		allowlist[method] = struct{}{}
+	}
+
+	return &methodExecutors{
+		executors:    make(map[string]failsafe.Executor[any]),
+		breakers:     make(map[string]circuitbreaker.CircuitBreaker[any]),
+		newBreaker:   newBreaker,
+		retry:        retry,
+		retryMethods: allowlist,
+	}
+}
+
+// get returns the executor of the method, and whether the calls to the method are retried. The retry policy is composed
+// inside the circuit breaker so that the breaker only records the outcome of a call once all of its retries are done.
+func (m *methodExecutors) get(method string) (failsafe.Executor[any], bool) {
+	_, retried := m.retryMethods[method]
+	retried = retried && m.retry != nil
+
+	m.mu.RLock()
+	executor, exists := m.executors[method]
+	m.mu.RUnlock()
+
+	if exists {
+		return executor, retried
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if executor, exists = m.executors[method]; exists {
+		return executor, retried
+	}
+
+	policies := []failsafe.Policy[any]{m.breakerLocked(method)}
+	if retried {
+		policies = append(policies, m.retry)
+	}
+
+	executor = failsafe.NewExecutor[any](policies...)
+	m.executors[method] = executor
+
+	return executor, retried
+}
+
+// breaker returns the circuit breaker of the method, which is shared by the unary and the streaming calls to the method
+func (m *methodExecutors) breaker(method string) circuitbreaker.CircuitBreaker[any] {
+	m.mu.RLock()
+	cb, exists := m.breakers[method]
+	m.mu.RUnlock()
+
+	if exists {
+		return cb
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.breakerLocked(method)
+}
+
+// breakerLocked returns the circuit breaker of the method, creating it on first use. It requires the write lock.
+func (m *methodExecutors) breakerLocked(method string) circuitbreaker.CircuitBreaker[any] {
+	if cb, exists := m.breakers[method]; exists {
+		return cb
+	}
+
+	cb := m.newBreaker(method)
+	m.breakers[method] = cb
+
+	return cb
+}
+
+// isForcedOpen reports whether the calls to the method are rejected by an override of its circuit breaker
+func (m *methodExecutors) isForcedOpen(method string) bool {
+	return m.forcedOpen != nil && m.forcedOpen(method)
+}
+
+// newFailsafeUnaryInterceptor guards every call with the circuit breaker of its method, calls to the allowlisted methods
+// are retried as well and their outcome is recorded in the retry budget of the client
+func newFailsafeUnaryInterceptor(executors *methodExecutors, budget *retryBudget) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if executors.isForcedOpen(method) {
+			return circuitbreaker.ErrOpen
+		}
+
+		executor, retried := executors.get(method)
+
+		ctx = context.WithValue(ctx, methodCtxKey{}, method)
+		_, err := executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+			err := invoker(exec.Context(), method, req, reply, cc, opts...)
+
+			if retried {
+				switch {
+				case err == nil:
+					budget.onSuccess()
+				case isRetryableError(err):
+					budget.onFailure()
+				}
+			}
+
+			return reply, err
+		})
+
+		return err
+	}
+}
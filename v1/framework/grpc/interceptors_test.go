@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func failingInvoker(failures int, calls *int) grpc.UnaryInvoker {
+	return func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+}
+
+func Test_newFailsafeUnaryInterceptor(t *testing.T) {
+	retryConfig := config.RetryClientConfig{MaxRetries: 3, Delay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	retryMethod := "/user.User/GetUser"
+
+	tests := []struct {
+		name      string
+		method    string
+		failures  int
+		budget    *retryBudget
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "allowlisted method is retried",
+			method:    retryMethod,
+			failures:  2,
+			budget:    newRetryBudget(10, 0.1),
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "method not in allowlist is not retried",
+			method:    "/user.User/UpdateUser",
+			failures:  2,
+			budget:    newRetryBudget(10, 0.1),
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			name:      "retries stop when budget is exhausted",
+			method:    retryMethod,
+			failures:  5,
+			budget:    newRetryBudget(2, 0.1),
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			name:      "last failure is returned after max retries",
+			method:    retryMethod,
+			failures:  10,
+			budget:    newRetryBudget(100, 0.1),
+			wantCalls: 4,
+			wantCode:  codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry := initRetryer(retryConfig, tt.budget, nil, "test-client")
+			executors := newMethodExecutors(func(string) circuitbreaker.CircuitBreaker[any] {
+				return circuitbreaker.WithDefaults[any]()
+			}, retry, []string{retryMethod})
+			interceptor := newFailsafeUnaryInterceptor(executors, tt.budget)
+
+			calls := 0
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, failingInvoker(tt.failures, &calls))
+
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func Test_methodExecutorsBreakerPerMethod(t *testing.T) {
+	var breakers []string
+	executors := newMethodExecutors(func(method string) circuitbreaker.CircuitBreaker[any] {
+		breakers = append(breakers, method)
+		return circuitbreaker.Builder[any]().WithFailureThreshold(1).WithDelay(time.Minute).Build()
+	}, nil, nil)
+	interceptor := newFailsafeUnaryInterceptor(executors, nil)
+
+	failingMethod, healthyMethod := "/resource.Resource/GetAncestorsOfAFacility", "/resource.Resource/GetResource"
+
+	calls := 0
+	err := interceptor(context.Background(), failingMethod, nil, nil, nil, failingInvoker(10, &calls))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// circuit of the failing method is open, the call is rejected without invoking the downstream
+	err = interceptor(context.Background(), failingMethod, nil, nil, nil, failingInvoker(10, &calls))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Equal(t, 1, calls)
+
+	healthyCalls := 0
+	err = interceptor(context.Background(), healthyMethod, nil, nil, nil, failingInvoker(0, &healthyCalls))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, healthyCalls)
+
+	assert.Equal(t, []string{failingMethod, healthyMethod}, breakers)
+}
+
+func Test_retryBudget(t *testing.T) {
+	budget := newRetryBudget(4, 0.5)
+	assert.True(t, budget.allowRetry())
+
+	budget.onFailure()
+	budget.onFailure()
+	assert.False(t, budget.allowRetry())
+
+	budget.onSuccess()
+	assert.True(t, budget.allowRetry())
+
+	for i := 0; i < 10; i++ {
+		budget.onSuccess()
+	}
+	assert.Equal(t, float64(4), budget.tokens)
+}
@@ -38,11 +38,25 @@ func (m *MockISafeGrpcPool) EXPECT() *MockISafeGrpcPoolMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockISafeGrpcPool) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockISafeGrpcPoolMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockISafeGrpcPool)(nil).Close))
+}
+
 // CreateConnectionForClient mocks base method.
-func (m *MockISafeGrpcPool) CreateConnectionForClient(ctx echo.Context, log logger.Logger, client string, cfg *config.Config, meter metric.Meter) (*grpc.ClientConn, error) {
+func (m *MockISafeGrpcPool) CreateConnectionForClient(ctx echo.Context, log logger.Logger, client string, cfg *config.Config, meter metric.Meter) (grpc.ClientConnInterface, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateConnectionForClient", ctx, log, client, cfg, meter)
-	ret0, _ := ret[0].(*grpc.ClientConn)
+	ret0, _ := ret[0].(grpc.ClientConnInterface)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -54,10 +68,10 @@ func (mr *MockISafeGrpcPoolMockRecorder) CreateConnectionForClient(ctx, log, cli
 }
 
 // GetConnectionForClient mocks base method.
-func (m *MockISafeGrpcPool) GetConnectionForClient(client string) (*grpc.ClientConn, bool) {
+func (m *MockISafeGrpcPool) GetConnectionForClient(client string) (grpc.ClientConnInterface, bool) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetConnectionForClient", client)
-	ret0, _ := ret[0].(*grpc.ClientConn)
+	ret0, _ := ret[0].(grpc.ClientConnInterface)
 	ret1, _ := ret[1].(bool)
 	return ret0, ret1
 }
@@ -68,8 +82,22 @@ func (mr *MockISafeGrpcPoolMockRecorder) GetConnectionForClient(client interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionForClient", reflect.TypeOf((*MockISafeGrpcPool)(nil).GetConnectionForClient), client)
 }
 
+// GetConnections mocks base method.
+func (m *MockISafeGrpcPool) GetConnections() map[string]grpc.ClientConnInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnections")
+	ret0, _ := ret[0].(map[string]grpc.ClientConnInterface)
+	return ret0
+}
+
+// GetConnections indicates an expected call of GetConnections.
+func (mr *MockISafeGrpcPoolMockRecorder) GetConnections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnections", reflect.TypeOf((*MockISafeGrpcPool)(nil).GetConnections))
+}
+
 // SetConnectionForClient mocks base method.
-func (m *MockISafeGrpcPool) SetConnectionForClient(client string, conn *grpc.ClientConn) {
+func (m *MockISafeGrpcPool) SetConnectionForClient(client string, conn grpc.ClientConnInterface) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetConnectionForClient", client, conn)
 }
@@ -1,9 +1,14 @@
 package grpc
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/circuitbreaker"
-	"github.com/failsafe-go/failsafe-go/failsafegrpc"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc/codes"
@@ -21,7 +26,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/faults"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
 )
 
 const (
@@ -31,17 +39,21 @@ const (
 )
 
 type ISafeGrpcConnections interface {
-	GetConnectionForClient(client string) (*grpc.ClientConn, bool)
-	SetConnectionForClient(client string, conn *grpc.ClientConn)
-	CreateConnectionForClient(ctx echo.Context, log logger.Logger, client string, cfg *config.Config, meter metric.Meter) (*grpc.ClientConn, error)
+	GetConnectionForClient(client string) (grpc.ClientConnInterface, bool)
+	GetConnections() map[string]grpc.ClientConnInterface
+	SetConnectionForClient(client string, conn grpc.ClientConnInterface)
+	CreateConnectionForClient(ctx echo.Context, log logger.Logger, client string, cfg *config.Config, meter metric.Meter) (grpc.ClientConnInterface, error)
+	Close() error
 }
 
+// SafeGrpcConnections holds the connection of every client, which is either a single *grpc.ClientConn or a pool of
+// connections when `<client>.pool.*` is configured
 type SafeGrpcConnections struct {
 	rwMutex           sync.RWMutex
-	clientConnections map[string]*grpc.ClientConn
+	clientConnections map[string]grpc.ClientConnInterface
 }
 
-func (safeGrpcConnections *SafeGrpcConnections) GetConnectionForClient(client string) (*grpc.ClientConn, bool) {
+func (safeGrpcConnections *SafeGrpcConnections) GetConnectionForClient(client string) (grpc.ClientConnInterface, bool) {
 	safeGrpcConnections.rwMutex.RLock()
 	defer safeGrpcConnections.rwMutex.RUnlock()
 	val, exists := safeGrpcConnections.clientConnections[client]
@@ -49,27 +61,193 @@ func (safeGrpcConnections *SafeGrpcConnections) GetConnectionForClient(client st
 	return val, exists
 }
 
-func (safeGrpcConnections *SafeGrpcConnections) SetConnectionForClient(client string, conn *grpc.ClientConn) {
+// GetConnections returns a copy of the connections of every client
+func (safeGrpcConnections *SafeGrpcConnections) GetConnections() map[string]grpc.ClientConnInterface {
+	safeGrpcConnections.rwMutex.RLock()
+	defer safeGrpcConnections.rwMutex.RUnlock()
+
+	conns := make(map[string]grpc.ClientConnInterface, len(safeGrpcConnections.clientConnections))
+	for client, conn := range safeGrpcConnections.clientConnections {
+		conns[client] = conn
+	}
+
+	return conns
+}
+
+func (safeGrpcConnections *SafeGrpcConnections) SetConnectionForClient(client string, conn grpc.ClientConnInterface) {
 	safeGrpcConnections.rwMutex.Lock()
 	defer safeGrpcConnections.rwMutex.Unlock()
 	safeGrpcConnections.clientConnections[client] = conn
 }
 
-func (*SafeGrpcConnections) CreateConnectionForClient(ctx echo.Context, log logger.Logger, client string, cfg *config.Config, meter metric.Meter) (*grpc.ClientConn, error) {
+// Close closes the connection of every client and forgets them, so that a later call creates new connections
+func (safeGrpcConnections *SafeGrpcConnections) Close() error {
+	safeGrpcConnections.rwMutex.Lock()
+	conns := safeGrpcConnections.clientConnections
+	safeGrpcConnections.clientConnections = make(map[string]grpc.ClientConnInterface)
+	safeGrpcConnections.rwMutex.Unlock()
+
+	var errs []error
+
+	for client, conn := range conns {
+		closer, ok := conn.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing connection for client: %s, err: %w", client, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (*SafeGrpcConnections) CreateConnectionForClient(ctx echo.Context, log logger.Logger, client string, cfg *config.Config, meter metric.Meter) (grpc.ClientConnInterface, error) {
 	conf := config.GetClientConfigs(client, cfg)
 
-	svcCircuitBreakerConfig := config.GetCircuitBreakerClientConfigs(client, cfg)
-	cb := initCircuitBreaker(ctx, log, svcCircuitBreakerConfig, meter, client)
+	cbMetrics, err := metrics.NewCircuitBreakerMetrics(meter)
+	if err != nil {
+		log.WithContext(ctx).Errorf("error creating circuit breaker metrics: %v", err)
+	}
+
+	// retries are opt-in per method, as retrying non-idempotent apis may cause duplicate writes
+	svcRetryConfig := config.GetRetryClientConfigs(client, cfg)
 
-	// note: not adding retry for now as it may cause idempotency issues for some apis
-	// svcRetryConfig := config.GetRetryClientConfigs(client, cfg)
-	// retry := initRetryer(svcRetryConfig)
+	var (
+		retry  retrypolicy.RetryPolicy[any]
+		budget *retryBudget
+	)
 
-	// Create gRPC client interceptor with retry and circuit breaker
-	interceptor := failsafegrpc.NewUnaryClientInterceptor[any](cb)
+	if len(svcRetryConfig.Methods) > 0 {
+		log.WithContext(ctx).Infof("enabling retries for client: %s, methods: %v", client, svcRetryConfig.Methods)
 
-	credential := getCredentials(conf.Endpoint)
-	conn, err := grpc.NewClient(conf.Endpoint,
+		retryMetrics, err := metrics.NewRetryMetrics(meter)
+		if err != nil {
+			log.WithContext(ctx).Errorf("error creating retry metrics: %v", err)
+		}
+
+		budget = newRetryBudget(svcRetryConfig.BudgetMaxTokens, svcRetryConfig.BudgetTokenRatio)
+		retry = initRetryer(svcRetryConfig, budget, retryMetrics, client)
+	}
+
+	// circuit breakers are created per method on the first call, so that one failing method does not open the circuit
+	// for every method of the client. They outlive the request which creates the connection, so they keep no echo
+	// context, echo reuses them for later requests.
+	executors := newMethodExecutors(func(method string) circuitbreaker.CircuitBreaker[any] {
+		return initCircuitBreaker(log, config.GetCircuitBreakerMethodConfigs(client, method, cfg), cbMetrics, client, method)
+	}, retry, svcRetryConfig.Methods)
+	executors.forcedOpen = func(method string) bool {
+		return defaultBreakers.forcedOpen(client, method)
+	}
+
+	clientMetrics, err := metrics.NewClientMetrics(meter)
+	if err != nil {
+		log.WithContext(ctx).Errorf("error creating grpc client metrics: %v", err)
+	}
+
+	// Create gRPC client interceptors with metadata propagation, metrics, bulkhead, retry and circuit breaker
+	interceptors := []grpc.UnaryClientInterceptor{propagation.UnaryClientInterceptor()}
+
+	// the last good responses of read methods are opt-in per method, they are served outside the metrics interceptor so
+	// that the failures of the downstream are still recorded
+	if svcFallbackConfig := config.GetFallbackClientConfigs(client, cfg); len(svcFallbackConfig.Methods) > 0 {
+		log.WithContext(ctx).Infof("enabling fallback for client: %s, methods: %v, max entries: %d, max age: %v", client,
+			svcFallbackConfig.Methods, svcFallbackConfig.MaxEntries, svcFallbackConfig.MaxAge)
+
+		fallbackMetrics, err := metrics.NewFallbackMetrics(meter)
+		if err != nil {
+			log.WithContext(ctx).Errorf("error creating fallback metrics: %v", err)
+		}
+
+		interceptors = append(interceptors, newFallbackUnaryInterceptor(client, svcFallbackConfig, fallbackMetrics))
+	}
+
+	interceptors = append(interceptors, newMetricsUnaryInterceptor(client, clientMetrics))
+
+	// the bulkhead is opt-in per client, it runs outside the failsafe interceptor so that a call holds its permit for
+	// all of its retries and hedges
+	if svcBulkheadConfig := config.GetBulkheadClientConfigs(client, cfg); svcBulkheadConfig.MaxConcurrency > 0 {
+		log.WithContext(ctx).Infof("enabling bulkhead for client: %s, max concurrency: %d, max wait: %v", client,
+			svcBulkheadConfig.MaxConcurrency, svcBulkheadConfig.MaxWait)
+
+		bulkheadMetrics, err := metrics.NewBulkheadMetrics(meter)
+		if err != nil {
+			log.WithContext(ctx).Errorf("error creating bulkhead metrics: %v", err)
+		}
+
+		interceptors = append(interceptors, newBulkheadUnaryInterceptor(client, svcBulkheadConfig, bulkheadMetrics))
+	}
+
+	interceptors = append(interceptors, newFailsafeUnaryInterceptor(executors, budget))
+
+	// hedging is opt-in per method as well, it runs inside the failsafe interceptor so that the circuit breaker and
+	// retries see a single call
+	svcHedgeConfig := config.GetHedgeClientConfigs(client, cfg)
+	if len(svcHedgeConfig.Methods) > 0 {
+		log.WithContext(ctx).Infof("enabling hedging for client: %s, methods: %v, delay: %v", client, svcHedgeConfig.Methods, svcHedgeConfig.Delay)
+
+		hedgeMetrics, err := metrics.NewHedgeMetrics(meter)
+		if err != nil {
+			log.WithContext(ctx).Errorf("error creating hedge metrics: %v", err)
+		}
+
+		interceptors = append(interceptors,
+			newHedgingUnaryInterceptor(client, svcHedgeConfig, newHedgeBudget(svcHedgeConfig.BudgetRatio), hedgeMetrics))
+	}
+
+	// the rate limit is opt-in per client, it runs inside the circuit breaker, retries and hedging
+	if svcRateLimitConfig := config.GetRateLimitClientConfigs(client, cfg); svcRateLimitConfig.RPS > 0 {
+		log.WithContext(ctx).Infof("enabling rate limit for client: %s, rps: %v, burst: %d, mode: %s", client,
+			svcRateLimitConfig.RPS, svcRateLimitConfig.Burst, svcRateLimitConfig.Mode)
+
+		rateLimitMetrics, err := metrics.NewRateLimitMetrics(meter)
+		if err != nil {
+			log.WithContext(ctx).Errorf("error creating rate limit metrics: %v", err)
+		}
+
+		interceptors = append(interceptors, newRateLimitUnaryInterceptor(client, svcRateLimitConfig, rateLimitMetrics))
+	}
+
+	// faults are injected for chaos testing outside of prod only, see faults.RulesKey
+	if injector := faults.NewInjector(cfg, log); injector.Enabled() {
+		interceptors = append(interceptors, newFaultUnaryInterceptor(log, client, injector))
+	}
+
+	// calls are recorded to or replayed from cassettes in tests and local runs only, never in prod
+	if svcCassetteConfig := config.GetCassetteClientConfigs(client, cfg); svcCassetteConfig.Mode != "" && os.Getenv(utils.Env) != utils.EnvProd {
+		log.WithContext(ctx).Infof("enabling cassette for client: %s, mode: %s, dir: %s", client, svcCassetteConfig.Mode, svcCassetteConfig.Dir)
+
+		cassetteInterceptor, err := newCassetteUnaryInterceptor(log, client, svcCassetteConfig)
+		if err != nil {
+			log.WithContext(ctx).Errorf("error creating cassette for client: %s, err: %v", client, err)
+			return nil, err
+		}
+
+		interceptors = append(interceptors, cassetteInterceptor)
+	}
+
+	// the readiness probes of the downstreams skip every interceptor, see CheckConnections
+	for i, interceptor := range interceptors {
+		interceptors[i] = skipOnHealthProbe(interceptor)
+	}
+
+	// streams get the same metadata, metrics and circuit breaker as unary calls, they are bounded by the timeout of the
+	// client when the caller sets no deadline and are never retried
+	streamInterceptors := []grpc.StreamClientInterceptor{
+		propagation.StreamClientInterceptor(),
+		newMetricsStreamInterceptor(client, clientMetrics),
+		newDeadlineStreamInterceptor(conf.Timeout),
+		newBreakerStreamInterceptor(executors),
+	}
+
+	credential, err := getClientCredentials(conf.Endpoint, config.GetTLSClientConfigs(client, cfg))
+	if err != nil {
+		log.WithContext(ctx).Errorf("error creating transport credentials for client: %s, err: %v", client, err)
+		return nil, err
+	}
+
+	dial, err := newClientDial(conf.Endpoint, config.GetDialClientConfigs(client, cfg),
 		grpc.WithTransportCredentials(credential),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 		grpc.WithConnectParams(grpc.ConnectParams{
@@ -79,53 +257,116 @@ func (*SafeGrpcConnections) CreateConnectionForClient(ctx echo.Context, log logg
 				Multiplier: BackoffMultiplier,
 			},
 		}),
-		grpc.WithUnaryInterceptor(interceptor),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithChainStreamInterceptor(streamInterceptors...),
 	)
 	if err != nil {
-		log.WithContext(ctx).Errorf("error trying to dial grpc connection, err: %v", err)
+		log.WithContext(ctx).Errorf("error creating dial options for client: %s, err: %v", client, err)
 		return nil, err
 	}
 
-	return conn, nil
+	svcPoolConfig := config.GetPoolClientConfigs(client, cfg)
+	if svcPoolConfig.Size == 1 && svcPoolConfig.IdleTimeout == 0 && svcPoolConfig.MaxLifetime == 0 {
+		conn, err := dial()
+		if err != nil {
+			log.WithContext(ctx).Errorf("error trying to dial grpc connection, err: %v", err)
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	log.WithContext(ctx).Infof("creating grpc connection pool for client: %s, size: %d", client, svcPoolConfig.Size)
+
+	pool, err := newConnPool(log, client, svcPoolConfig.Size, svcPoolConfig.IdleTimeout, svcPoolConfig.MaxLifetime, dial)
+	if err != nil {
+		log.WithContext(ctx).Errorf("error trying to dial grpc connection pool, err: %v", err)
+		return nil, err
+	}
+
+	return pool, nil
 }
 
-func initRetryer(svcRetryConfig config.RetryClientConfig) retrypolicy.RetryPolicy[any] {
-	// Define the set of gRPC codes to be checked
-	grpcCodeSet := map[codes.Code]struct{}{
-		codes.Internal:          {},
-		codes.Unavailable:       {},
-		codes.DeadlineExceeded:  {},
-		codes.ResourceExhausted: {},
+func initRetryer(svcRetryConfig config.RetryClientConfig, budget *retryBudget, retryMetrics *metrics.RetryMetrics, client string) retrypolicy.RetryPolicy[any] {
+	var attemptCount, exceededCount, budgetExhaustedCount metric.Int64Counter
+	if retryMetrics != nil {
+		attemptCount = retryMetrics.AttemptCount
+		exceededCount = retryMetrics.ExceededCount
+		budgetExhaustedCount = retryMetrics.BudgetExhaustedCount
 	}
+
 	retry := retrypolicy.Builder[any]().
 		HandleIf(func(_ any, err error) bool {
-			if err == nil {
-				return false
-			}
-			grpcCode := status.Code(err)
-			// Check if the gRPC code is in the set
-			_, found := grpcCodeSet[grpcCode]
-			return found
-		}).WithMaxRetries(svcRetryConfig.MaxRetries).
-		WithDelay(svcRetryConfig.Delay).
+			return isRetryableError(err)
+		}).
+		// stop retrying once the budget is exhausted, the last failure is returned as is
+		AbortIf(func(_ any, err error) bool {
+			return isRetryableError(err) && !budget.allowRetry()
+		}).
+		ReturnLastFailure().
+		WithMaxRetries(svcRetryConfig.MaxRetries).
+		WithBackoff(svcRetryConfig.Delay, svcRetryConfig.MaxDelay).
+		WithJitterFactor(svcRetryConfig.JitterFactor).
+		OnRetry(onRetryEventWrapper(attemptCount, client)).
+		OnRetriesExceeded(onRetryEventWrapper(exceededCount, client)).
+		OnAbort(onRetryEventWrapper(budgetExhaustedCount, client)).
 		Build()
 
 	return retry
 }
 
+// isRetryableError checks if the grpc code of the error is one of the transient codes which can be retried
+func isRetryableError(err error) bool {
+	if err == nil || errors.Is(err, ErrRateLimited) {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Internal, codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func onRetryEventWrapper(counter metric.Int64Counter, client string) func(failsafe.ExecutionEvent[any]) {
+	return func(event failsafe.ExecutionEvent[any]) {
+		if counter == nil {
+			return
+		}
+
+		paramsMap := map[string]string{
+			clientNameAttr: client,
+			methodAttr:     methodFromContext(event.Context()),
+		}
+		metrics.AddCounter(counter, event.Context(), "Retry", paramsMap)
+	}
+}
+
+// onStateChangeWrapper logs and records the state changes of a circuit breaker, they are not made by a request so they
+// are recorded without the context of one
 func onStateChangeWrapper(currentStateStartTime time.Time, cbMetrics *metrics.CircuitBreakerMetrics,
-	ctxx echo.Context, client string, log logger.Logger) func(circuitbreaker.StateChangedEvent) {
+	client, method string, log logger.Logger) func(circuitbreaker.StateChangedEvent) {
 	return func(event circuitbreaker.StateChangedEvent) {
-		log.WithContext(ctxx).Infof("circuit breaker state changed for client-%s from %s to %s", client, event.OldState, event.NewState)
+		if !defaultBreakers.reporting(client, method) {
+			return
+		}
+
+		log.Infof("circuit breaker state changed for client-%s method-%s from %s to %s", client, method, event.OldState, event.NewState)
+		defaultBreakers.stateChanged(client, method)
+
+		if cbMetrics == nil {
+			return
+		}
 
-		clientName := "client-name"
 		state := "state"
 		paramsMap := map[string]string{
-			clientName: client,
-			state:      event.NewState.String(),
+			clientNameAttr: client,
+			methodAttr:     method,
+			state:          event.NewState.String(),
 		}
 		circuitBreaker := "CircuitBreaker"
-		ctx := ctxx.Request().Context()
+		ctx := context.Background()
 		metrics.AddCounter(cbMetrics.StateChangeCount, ctx, circuitBreaker, paramsMap)
 		paramsMap[state] = event.OldState.String()
 		duration := time.Since(currentStateStartTime)
@@ -135,8 +376,8 @@ func onStateChangeWrapper(currentStateStartTime time.Time, cbMetrics *metrics.Ci
 	}
 }
 
-func initCircuitBreaker(ctx echo.Context, log logger.Logger, svcCircuitBreakerConfig config.CircuitBreakerClientConfig,
-	meter metric.Meter, client string) circuitbreaker.CircuitBreaker[any] {
+func initCircuitBreaker(log logger.Logger, svcCircuitBreakerConfig config.CircuitBreakerClientConfig,
+	cbMetrics *metrics.CircuitBreakerMetrics, client, method string) circuitbreaker.CircuitBreaker[any] {
 	// Define the set of gRPC codes to be checked
 	grpcCodeSet := map[codes.Code]struct{}{
 		codes.Internal:          {},
@@ -146,15 +387,15 @@ func initCircuitBreaker(ctx echo.Context, log logger.Logger, svcCircuitBreakerCo
 	}
 
 	currentStateStartTime := time.Now()
-	circuitBreakerMetric, err := metrics.NewCircuitBreakerMetrics(meter)
-
-	if err != nil {
-		log.WithContext(ctx).Errorf("error creating circuit breaker metrics: %v", err)
-	}
 
 	cb := circuitbreaker.Builder[any]().
 		HandleIf(func(_ any, err error) bool {
-			if err == nil {
+			// calls rejected by the rate limit of the client never reached the downstream
+			if err == nil || errors.Is(err, ErrRateLimited) {
+				return false
+			}
+			// a breaker forced closed by an operator records no failure until the override expires
+			if defaultBreakers.forcedState(client, method) == BreakerForcedClosed {
 				return false
 			}
 			grpcCode := status.Code(err)
@@ -167,7 +408,9 @@ func initCircuitBreaker(ctx echo.Context, log logger.Logger, svcCircuitBreakerCo
 			svcCircuitBreakerConfig.FailurePeriodThreshold).
 		WithDelay(svcCircuitBreakerConfig.Delay).
 		WithSuccessThreshold(svcCircuitBreakerConfig.SuccessThreshold).
-		OnStateChanged(onStateChangeWrapper(currentStateStartTime, circuitBreakerMetric, ctx, client, log)).Build()
+		OnStateChanged(onStateChangeWrapper(currentStateStartTime, cbMetrics, client, method, log)).Build()
+
+	defaultBreakers.register(client, method, cb, svcCircuitBreakerConfig)
 
 	return cb
 }
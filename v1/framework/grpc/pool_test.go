@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"net/http"
@@ -125,13 +126,11 @@ func Test_initCircuitBreaker(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockContext := echo.New().NewContext(nil, nil)
 	mockLogger := logger.NewAPILogger(&config.Config{Logger: config.Logger{Level: "info"}})
 	mockLogger.InitLogger()
 	_, _, _, _, m, _, _ := getTestingParams(t)
 
 	type args struct {
-		ctx                     echo.Context
 		log                     logger.Logger
 		svcCircuitBreakerConfig config.CircuitBreakerClientConfig
 	}
@@ -143,7 +142,6 @@ func Test_initCircuitBreaker(t *testing.T) {
 		{
 			name: "Circuit breaker initialization",
 			args: args{
-				ctx: mockContext,
 				log: mockLogger,
 				svcCircuitBreakerConfig: config.CircuitBreakerClientConfig{
 					FailurePercentageThresholdWithinTimePeriod:   50,
@@ -158,7 +156,12 @@ func Test_initCircuitBreaker(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cb := initCircuitBreaker(tt.args.ctx, tt.args.log, tt.args.svcCircuitBreakerConfig, m, "")
+			cbMetrics, err := metrics.NewCircuitBreakerMetrics(m)
+			if err != nil {
+				t.Errorf("NewCircuitBreakerMetrics() returned %v", err)
+			}
+
+			cb := initCircuitBreaker(tt.args.log, tt.args.svcCircuitBreakerConfig, cbMetrics, "", "")
 
 			if cb == nil {
 				t.Errorf("initInstrumentedCircuitBreaker() returned nil")
@@ -207,7 +210,7 @@ func Test_initRetryer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			retry := initRetryer(tt.args.svcRetryConfig)
+			retry := initRetryer(tt.args.svcRetryConfig, newRetryBudget(10, 0.1), nil, "test-client")
 
 			if retry == nil {
 				t.Errorf("initRetryer() returned nil")
@@ -217,7 +220,7 @@ func Test_initRetryer(t *testing.T) {
 }
 
 func Test_onStateChangeWrapper(t *testing.T) {
-	_, _, _, log, m, ctx, _ := getTestingParams(t)
+	_, _, _, log, m, _, _ := getTestingParams(t)
 	cbMetric, err := metrics.NewCircuitBreakerMetrics(m)
 	if err != nil {
 		t.Errorf("NewCircuitBreakerMetrics() returned %v", err)
@@ -225,8 +228,8 @@ func Test_onStateChangeWrapper(t *testing.T) {
 	type args struct {
 		currentStateStartTime time.Time
 		cbMetrics             *metrics.CircuitBreakerMetrics
-		context               echo.Context
 		client                string
+		method                string
 		log                   logger.Logger
 	}
 	tests := []struct {
@@ -238,14 +241,22 @@ func Test_onStateChangeWrapper(t *testing.T) {
 			args: args{
 				currentStateStartTime: time.Now(),
 				cbMetrics:             cbMetric,
-				context:               ctx,
+				log:                   log,
+			},
+		},
+		{
+			name: "nil metrics",
+			args: args{
+				currentStateStartTime: time.Now(),
+				client:                "test-client",
+				method:                "/user.User/GetUser",
 				log:                   log,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			onStateChangeMethod := onStateChangeWrapper(tt.args.currentStateStartTime, tt.args.cbMetrics, tt.args.context, tt.args.client, tt.args.log)
+			onStateChangeMethod := onStateChangeWrapper(tt.args.currentStateStartTime, tt.args.cbMetrics, tt.args.client, tt.args.method, tt.args.log)
 			onStateChangeMethod(circuitbreaker.StateChangedEvent{})
 		})
 	}
@@ -254,8 +265,8 @@ func Test_onStateChangeWrapper(t *testing.T) {
 func TestSafeGrpcPools_GetConnectionPoolForClient(t *testing.T) {
 	client1 := "client1"
 	client2 := "client2"
-	connectionPools := map[string]*grpc.ClientConn{
-		"client1": {},
+	connectionPools := map[string]grpc.ClientConnInterface{
+		"client1": &grpc.ClientConn{},
 	}
 	tests := []struct {
 		name   string
@@ -298,9 +309,37 @@ func TestSafeGrpcPools_SetConnectionPoolForClient(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			grpcPools := &SafeGrpcConnections{
-				clientConnections: map[string]*grpc.ClientConn{},
+				clientConnections: map[string]grpc.ClientConnInterface{},
 			}
 			grpcPools.SetConnectionForClient("test-client", &grpc.ClientConn{})
 		})
 	}
 }
+
+func TestSafeGrpcConnections_Close(t *testing.T) {
+	_, _, _, log, _, _, _ := getTestingParams(t)
+
+	conn, err := testDial()
+	assert.NoError(t, err)
+
+	pool, err := newConnPool(log, "pool-client", 2, 0, 0, testDial)
+	assert.NoError(t, err)
+
+	closedConn, err := testDial()
+	assert.NoError(t, err)
+	closedConn.Close()
+
+	grpcPools := &SafeGrpcConnections{
+		clientConnections: map[string]grpc.ClientConnInterface{
+			"test-client":   conn,
+			"pool-client":   pool,
+			"closed-client": closedConn,
+		},
+	}
+
+	err = grpcPools.Close()
+	assert.ErrorContains(t, err, "closed-client")
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+	assert.Equal(t, connectivity.Shutdown, pool.GetState())
+	assert.Empty(t, grpcPools.GetConnections())
+}
@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+)
+
+// ErrRateLimited is returned for the calls rejected by the rate limit of a client, its ResourceExhausted code maps to
+// http.StatusTooManyRequests. It is neither retried nor recorded as a failure by the circuit breaker, as the
+// downstream never saw the call.
+var ErrRateLimited = status.Error(codes.ResourceExhausted, "too many calls to the downstream, client rate limit exceeded")
+
+// tokenBucket allows rate calls per second on average, and up to burst calls at once after an idle period. Tokens are
+// reserved ahead of time, so that the calls waiting for a token are sent in order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now(), now: time.Now}
+}
+
+// reserve takes a token and returns the time to wait until it is available, no token is taken when the wait would be
+// longer than maxWait
+func (b *tokenBucket) reserve(maxWait time.Duration) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	var wait time.Duration
+	if b.tokens < 1 {
+		wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+
+	if wait > maxWait {
+		return 0, false
+	}
+
+	b.tokens--
+
+	return wait, true
+}
+
+// cancel gives back the token of a reservation which was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// newRateLimitUnaryInterceptor limits the rate of the calls sent to the downstream of a client, so that bursts stay
+// within the quota of the downstream. In wait mode a call waits for a token as long as its deadline allows, in reject
+// mode it is rejected right away. It runs inside the circuit breaker, retries and hedging, so that every attempt sent
+// to the downstream takes a token.
+func newRateLimitUnaryInterceptor(client string, rateLimitConfig config.RateLimitClientConfig,
+	rateLimitMetrics *metrics.RateLimitMetrics) grpc.UnaryClientInterceptor {
+	var throttledCount, rejectedCount metric.Int64Counter
+	if rateLimitMetrics != nil {
+		throttledCount = rateLimitMetrics.ThrottledCount
+		rejectedCount = rateLimitMetrics.RejectedCount
+	}
+
+	bucket := newTokenBucket(rateLimitConfig.RPS, rateLimitConfig.Burst)
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		paramsMap := map[string]string{
+			clientNameAttr: client,
+			methodAttr:     method,
+		}
+
+		maxWait := time.Duration(0)
+		if rateLimitConfig.Mode == config.RateLimitModeWait {
+			maxWait = time.Duration(math.MaxInt64)
+			if deadline, ok := ctx.Deadline(); ok {
+				maxWait = time.Until(deadline)
+			}
+		}
+
+		wait, ok := bucket.reserve(maxWait)
+		if !ok {
+			if rejectedCount != nil {
+				metrics.AddCounter(rejectedCount, ctx, "RateLimit", paramsMap)
+			}
+
+			return ErrRateLimited
+		}
+
+		if wait > 0 {
+			if throttledCount != nil {
+				metrics.AddCounter(throttledCount, ctx, "RateLimit", paramsMap)
+			}
+
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				bucket.cancel()
+				return status.FromContextError(ctx.Err()).Err()
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_tokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(10, 2)
+	bucket.last, bucket.now = now, func() time.Time { return now }
+
+	// the burst is available right away
+	for range 2 {
+		wait, ok := bucket.reserve(0)
+		assert.True(t, ok)
+		assert.Zero(t, wait)
+	}
+
+	_, ok := bucket.reserve(50 * time.Millisecond)
+	assert.False(t, ok, "next token is 100ms away")
+
+	wait, ok := bucket.reserve(time.Second)
+	assert.True(t, ok)
+	assert.InDelta(t, 100*time.Millisecond, wait, float64(time.Millisecond))
+
+	// reservations queue behind each other
+	wait, ok = bucket.reserve(time.Second)
+	assert.True(t, ok)
+	assert.InDelta(t, 200*time.Millisecond, wait, float64(time.Millisecond))
+
+	bucket.cancel()
+	bucket.cancel()
+
+	now = now.Add(time.Second)
+	wait, ok = bucket.reserve(0)
+	assert.True(t, ok)
+	assert.Zero(t, wait)
+}
+
+func Test_newRateLimitUnaryInterceptor(t *testing.T) {
+	rateLimitMetrics, err := metrics.NewRateLimitMetrics(noop.NewMeterProvider().Meter("ratelimit-test"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		mode    string
+		timeout time.Duration
+		wantErr error
+		minWait time.Duration
+	}{
+		{
+			name:    "call over the rate is rejected in reject mode",
+			mode:    config.RateLimitModeReject,
+			timeout: time.Second,
+			wantErr: ErrRateLimited,
+		},
+		{
+			name:    "call over the rate waits for a token within its deadline",
+			mode:    config.RateLimitModeWait,
+			timeout: time.Second,
+			minWait: 40 * time.Millisecond,
+		},
+		{
+			name:    "call over the rate is rejected when its deadline is too close",
+			mode:    config.RateLimitModeWait,
+			timeout: 10 * time.Millisecond,
+			wantErr: ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := newRateLimitUnaryInterceptor("test-client",
+				config.RateLimitClientConfig{RPS: 20, Burst: 1, Mode: tt.mode}, rateLimitMetrics)
+
+			calls := 0
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				calls++
+				return nil
+			}
+
+			require.NoError(t, interceptor(context.Background(), "/user.User/GetUser", nil, nil, nil, invoker))
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := interceptor(ctx, "/user.User/GetUser", nil, nil, nil, invoker)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.GreaterOrEqual(t, time.Since(start), tt.minWait)
+
+			wantCalls := 2
+			if tt.wantErr != nil {
+				wantCalls = 1
+			}
+			assert.Equal(t, wantCalls, calls)
+		})
+	}
+}
+
+func TestErrRateLimited(t *testing.T) {
+	code, _ := utils.GetErrorCodeAndMessage(ErrRateLimited)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+
+	assert.False(t, isRetryableError(ErrRateLimited))
+	assert.True(t, isRetryableError(status.Error(codes.ResourceExhausted, "quota exceeded")))
+}
@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+)
+
+const (
+	// ConnReloadInterval is how often the config and certificates of a client are checked for changes of its connection
+	ConnReloadInterval = 30 * time.Second
+	// ConnDrainGracePeriod is how long a replaced connection keeps serving the calls in flight before it is closed
+	ConnDrainGracePeriod = 30 * time.Second
+)
+
+// connReloader tracks the config every client connection was built with, so that the connection is rebuilt once the
+// config of its client changes in dynamic config. The zero value is ready to use.
+type connReloader struct {
+	mu      sync.Mutex
+	clients map[string]*connState
+}
+
+type connState struct {
+	fingerprint string
+	checkedAt   time.Time
+}
+
+// connFingerprint returns the configs the connection of a client is built from, a change of it rebuilds the connection
+func connFingerprint(client string, cnf *config.Config) string {
+	return fmt.Sprintf("%s|%+v|%s", config.GetClientConfigs(client, cnf).Endpoint, config.GetDialClientConfigs(client, cnf),
+		tlsFingerprint(config.GetTLSClientConfigs(client, cnf)))
+}
+
+// track records the fingerprint of the new connection of the client
+func (r *connReloader) track(client, fingerprint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.clients == nil {
+		r.clients = make(map[string]*connState)
+	}
+
+	r.clients[client] = &connState{fingerprint: fingerprint, checkedAt: time.Now()}
+}
+
+// due reports whether the config of the client should be checked, which is at most once every ConnReloadInterval
+func (r *connReloader) due(client string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, exists := r.clients[client]
+	if !exists {
+		return true
+	}
+
+	if now.Sub(state.checkedAt) < ConnReloadInterval {
+		return false
+	}
+
+	state.checkedAt = now
+
+	return true
+}
+
+// changed reports whether the fingerprint differs from the one the connection of the client was built with, a
+// connection which is not tracked yet is assumed to be built with the given fingerprint
+func (r *connReloader) changed(client, fingerprint string) bool {
+	r.mu.Lock()
+	state, exists := r.clients[client]
+	r.mu.Unlock()
+
+	if !exists {
+		r.track(client, fingerprint)
+		return false
+	}
+
+	return state.fingerprint != fingerprint
+}
+
+// reloadConnIfChanged rebuilds the connection of the client when its config changed, new calls are swapped over to the
+// new connection right away and the old connection is closed after ConnDrainGracePeriod
+func (handler *Handler) reloadConnIfChanged(ctx echo.Context, log logger.Logger, client string, cnf *config.Config,
+	conn grpc.ClientConnInterface) grpc.ClientConnInterface {
+	if !handler.reloader.due(client, time.Now()) {
+		return conn
+	}
+
+	fingerprint := connFingerprint(client, cnf)
+	if !handler.reloader.changed(client, fingerprint) {
+		return conn
+	}
+
+	mutex := getOrCreateMutex(client)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// the connection may have been swapped by another call while waiting for the lock
+	if current, exists := handler.safeGrpcConnections.GetConnectionForClient(client); exists && current != conn {
+		return current
+	}
+
+	newConn, err := handler.safeGrpcConnections.CreateConnectionForClient(ctx, log, client, cnf, handler.meter)
+	if err != nil {
+		log.WithContext(ctx).Errorf("error rebuilding connection for client: %s after config change, err: %v", client, err)
+		handler.countConnSwap(ctx, client, "error")
+
+		return conn
+	}
+
+	handler.safeGrpcConnections.SetConnectionForClient(client, newConn)
+	handler.reloader.track(client, fingerprint)
+
+	log.WithContext(ctx).Infof("swapped connection for client: %s after config change, config: %s", client, fingerprint)
+	handler.countConnSwap(ctx, client, "success")
+
+	time.AfterFunc(ConnDrainGracePeriod, func() {
+		closeConn(log, client, conn)
+	})
+
+	return newConn
+}
+
+func (handler *Handler) countConnSwap(ctx echo.Context, client, result string) {
+	if handler.connMetrics == nil {
+		return
+	}
+
+	paramsMap := map[string]string{
+		clientNameAttr: client,
+		"result":       result,
+	}
+	metrics.AddCounter(handler.connMetrics.SwapCount, ctx.Request().Context(), "GrpcConn", paramsMap)
+}
+
+func closeConn(log logger.Logger, client string, conn grpc.ClientConnInterface) {
+	closer, ok := conn.(io.Closer)
+	if !ok {
+		return
+	}
+
+	if err := closer.Close(); err != nil {
+		log.Errorf("error closing replaced connection for client: %s, err: %v", client, err)
+		return
+	}
+
+	log.Infof("closed replaced connection for client: %s", client)
+}
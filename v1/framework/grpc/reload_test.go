@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+)
+
+func TestHandler_GetConnReloadsChangedEndpoint(t *testing.T) {
+	ctrl, _, _, log, _, ctx, _ := getTestingParams(t)
+	reader := sdkmetric.NewManualReader()
+	m := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("reload-test")
+	safeGrpcPoolMock := NewMockISafeGrpcPool(ctrl)
+	dc := config.NewMockDynamicConfig(ctrl)
+	cnf := &config.Config{DynamicConfig: dc}
+
+	client := "reload-client"
+	oldConn, newConn := &grpc.ClientConn{}, &grpc.ClientConn{}
+
+	dc.EXPECT().Get(client+config.ConnTimeoutSuffix).Return("", nil).AnyTimes()
+	dc.EXPECT().Get(client+config.TimeoutSuffix).Return("", nil).AnyTimes()
+	dc.EXPECT().Get(client+config.EndPointSuffix).Return("new-endpoint:443", nil).AnyTimes()
+	for _, suffix := range []string{config.TLSModeSuffix, config.TLSCAFileSuffix, config.TLSCertFileSuffix,
+		config.TLSKeyFileSuffix, config.TLSServerNameSuffix, config.ResolverSuffix, config.LBPolicySuffix,
+		config.KeepaliveTimeSuffix, config.KeepaliveTimeoutSuffix, config.KeepalivePermitWithoutStreamSuffix,
+		config.MaxRecvMsgSizeSuffix, config.MaxSendMsgSizeSuffix, config.CompressionSuffix, config.ServiceConfigSuffix} {
+		dc.EXPECT().Get(client+suffix).Return("", nil).AnyTimes()
+	}
+
+	gomock.InOrder(
+		safeGrpcPoolMock.EXPECT().GetConnectionForClient(client).Return(oldConn, true),
+		safeGrpcPoolMock.EXPECT().GetConnectionForClient(client).Return(oldConn, true),
+		safeGrpcPoolMock.EXPECT().CreateConnectionForClient(ctx, log, client, cnf, m).Return(newConn, nil),
+		safeGrpcPoolMock.EXPECT().SetConnectionForClient(client, newConn),
+		safeGrpcPoolMock.EXPECT().GetConnectionForClient(client).Return(newConn, true),
+	)
+
+	handler := NewGRPC(log, m)
+	handler.safeGrpcConnections = safeGrpcPoolMock
+	handler.reloader.track(client, "old-endpoint:443")
+	handler.reloader.clients[client].checkedAt = time.Now().Add(-ConnReloadInterval)
+
+	conn, err := handler.GetConn(ctx, log, client, cnf)
+	assert.NoError(t, err)
+	assert.Same(t, newConn, conn)
+
+	// config is not checked again within the reload interval
+	conn, err = handler.GetConn(ctx, log, client, cnf)
+	assert.NoError(t, err)
+	assert.Same(t, newConn, conn)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, map[string]int64{"success": 1}, sumByName(rm, "bff_service_grpc_conn_swap_count", "result"))
+}
+
+func Test_connReloader(t *testing.T) {
+	var r connReloader
+	now := time.Now()
+
+	assert.True(t, r.due("client", now))
+	assert.False(t, r.changed("client", "endpoint-1"))
+
+	assert.False(t, r.due("client", now))
+	assert.True(t, r.due("client", now.Add(2*ConnReloadInterval)))
+	assert.True(t, r.changed("client", "endpoint-2"))
+}
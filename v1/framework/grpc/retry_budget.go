@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"sync"
+)
+
+// retryBudget throttles retries of a client in the same way as gRPC retry throttling: every failed call removes a
+// token, every successful call adds tokenRatio tokens back, and retries are only allowed while more than half of the
+// tokens are left. This keeps retries from multiplying the load on a downstream which is already failing.
+type retryBudget struct {
+	mu         sync.Mutex
+	tokens     float64
+	maxTokens  float64
+	tokenRatio float64
+}
+
+func newRetryBudget(maxTokens, tokenRatio float64) *retryBudget {
+	return &retryBudget{tokens: maxTokens, maxTokens: maxTokens, tokenRatio: tokenRatio}
+}
+
+func (b *retryBudget) onSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.maxTokens, b.tokens+b.tokenRatio)
+}
+
+func (b *retryBudget) onFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = max(0, b.tokens-1)
+}
+
+func (b *retryBudget) allowRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.tokens > b.maxTokens/2
+}
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+// ErrStreamTooLong is returned by CollectStream for streams with more items than allowed
+var ErrStreamTooLong = status.Error(codes.OutOfRange, "stream has more items than allowed")
+
+// finishStream calls onFinish once with the outcome of the stream: nil once it is received to the end, the error of the
+// stream when it fails, or the error of its ctx when the caller stops receiving before the end
+type finishStream struct {
+	grpc.ClientStream
+
+	once     sync.Once
+	stop     func() bool
+	onFinish func(err error)
+}
+
+func newFinishStream(ctx context.Context, stream grpc.ClientStream, onFinish func(err error)) *finishStream {
+	s := &finishStream{ClientStream: stream, onFinish: onFinish}
+	s.stop = context.AfterFunc(ctx, func() {
+		s.finish(status.FromContextError(ctx.Err()).Err())
+	})
+
+	return s
+}
+
+func (s *finishStream) finish(err error) {
+	s.once.Do(func() {
+		s.stop()
+		s.onFinish(err)
+	})
+}
+
+func (s *finishStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	}
+
+	return err
+}
+
+// newMetricsStreamInterceptor records the request count, the error count by grpc code and the duration of the streams
+// of a client, like newMetricsUnaryInterceptor does for unary calls
+func newMetricsStreamInterceptor(client string, clientMetrics *metrics.ClientMetrics) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if clientMetrics == nil {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		start := time.Now()
+		record := func(err error) {
+			paramsMap := map[string]string{
+				clientNameAttr: client,
+				methodAttr:     method,
+				dataSourceAttr: utils.DataSourceFromContext(ctx),
+				cbRejectedAttr: strconv.FormatBool(errors.Is(err, circuitbreaker.ErrOpen)),
+			}
+
+			metrics.AddCounter(clientMetrics.RequestCount, ctx, "GrpcClient", paramsMap)
+			metrics.HistogramRecord(clientMetrics.Latency, ctx, "GrpcClient", paramsMap, time.Since(start))
+
+			if err != nil {
+				paramsMap[codeAttr] = status.Code(err).String()
+				metrics.AddCounter(clientMetrics.ErrorCount, ctx, "GrpcClient", paramsMap)
+			}
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			record(err)
+			return nil, err
+		}
+
+		return newFinishStream(ctx, stream, record), nil
+	}
+}
+
+// newDeadlineStreamInterceptor bounds the streams without a deadline by the timeout of the client, a stream holds its
+// connection and server resources until it ends
+func newDeadlineStreamInterceptor(timeout time.Duration) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		return newFinishStream(ctx, stream, func(error) { cancel() }), nil
+	}
+}
+
+// newBreakerStreamInterceptor guards every stream with the circuit breaker of its method, which is shared with the unary
+// calls to the method. The outcome of a stream is recorded once it ends, streams are never retried.
+func newBreakerStreamInterceptor(executors *methodExecutors) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cb := executors.breaker(method)
+		if executors.isForcedOpen(method) || !cb.TryAcquirePermit() {
+			return nil, circuitbreaker.ErrOpen
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cb.RecordError(err)
+			return nil, err
+		}
+
+		return newFinishStream(ctx, stream, cb.RecordError), nil
+	}
+}
+
+// StreamReceiver is the receiving side of a server stream, as implemented by the generated stream clients
+type StreamReceiver[T any] interface {
+	Recv() (T, error)
+}
+
+// CollectStream opens a server stream with the ctx of the request, bounded by the timeout and the remaining budget of
+// the request, and collects its items. It stops with ErrStreamTooLong and the first maxItems items when the stream has
+// more items, a maxItems of 0 collects every item. The stream is released when CollectStream returns.
+func CollectStream[T any](c echo.Context, timeout time.Duration, maxItems int,
+	open func(ctx context.Context) (StreamReceiver[T], error)) ([]T, error) {
+	ctx, cancel, err := utils.GetCallCtxWithBudget(c, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	stream, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []T
+
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+
+		if err != nil {
+			return items, err
+		}
+
+		if maxItems > 0 && len(items) == maxItems {
+			return items, ErrStreamTooLong
+		}
+
+		items = append(items, item)
+	}
+}
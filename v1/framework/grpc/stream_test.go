@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/otel/metrics"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClientStream receives n messages and then ends with err, or io.EOF when err is nil
+type fakeClientStream struct {
+	grpc.ClientStream
+	n   int
+	err error
+}
+
+func (s *fakeClientStream) RecvMsg(any) error {
+	if s.n > 0 {
+		s.n--
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	return io.EOF
+}
+
+func fakeStreamer(n int, err error) grpc.Streamer {
+	return func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{n: n, err: err}, nil
+	}
+}
+
+// drain receives the stream to its end and returns its error
+func drain(stream grpc.ClientStream) error {
+	for {
+		if err := stream.RecvMsg(nil); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+func Test_newBreakerStreamInterceptor(t *testing.T) {
+	executors := newMethodExecutors(func(string) circuitbreaker.CircuitBreaker[any] {
+		return circuitbreaker.Builder[any]().
+			HandleIf(func(_ any, err error) bool { return status.Code(err) == codes.Unavailable }).
+			WithFailureThreshold(2).
+			WithDelay(time.Minute).
+			Build()
+	}, nil, nil)
+
+	interceptor := newBreakerStreamInterceptor(executors)
+	method := "/page.Page/StreamWidgets"
+
+	// a stream stopped by the caller is not a failure of the downstream
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := interceptor(ctx, &grpc.StreamDesc{}, nil, method, fakeStreamer(5, nil))
+	require.NoError(t, err)
+	require.NoError(t, stream.RecvMsg(nil))
+	cancel()
+
+	for range 2 {
+		stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, method, fakeStreamer(1, status.Error(codes.Unavailable, "down")))
+		require.NoError(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(drain(stream)))
+	}
+
+	assert.Eventually(t, executors.breaker(method).IsOpen, time.Second, 10*time.Millisecond)
+
+	_, err = interceptor(context.Background(), &grpc.StreamDesc{}, nil, method, fakeStreamer(0, nil))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+
+	// unary calls to the method share its circuit breaker
+	executor, _ := executors.get(method)
+	assert.ErrorIs(t, executor.Run(func() error { return nil }), circuitbreaker.ErrOpen)
+	assert.True(t, executors.breaker("/page.Page/GetPage").IsClosed())
+}
+
+func Test_newMetricsStreamInterceptor(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	clientMetrics, err := metrics.NewClientMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("stream-test"))
+	require.NoError(t, err)
+
+	interceptor := newMetricsStreamInterceptor("test-client", clientMetrics)
+
+	for _, streamErr := range []error{nil, status.Error(codes.NotFound, "not found")} {
+		stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/page.Page/StreamWidgets", fakeStreamer(3, streamErr))
+		require.NoError(t, err)
+		assert.Equal(t, streamErr, drain(stream))
+		assert.Equal(t, streamErr, drain(stream), "outcome is recorded once")
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	assert.Equal(t, map[string]int64{"/page.Page/StreamWidgets": 2}, sumByName(rm, "bff_service_grpc_client_request_count", methodAttr))
+	assert.Equal(t, map[string]int64{"NotFound": 1}, sumByName(rm, "bff_service_grpc_client_error_count", codeAttr))
+}
+
+func Test_newDeadlineStreamInterceptor(t *testing.T) {
+	interceptor := newDeadlineStreamInterceptor(time.Second)
+
+	var streamCtx context.Context
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamCtx = ctx
+		return &fakeClientStream{}, nil
+	}
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/page.Page/StreamWidgets", streamer)
+	require.NoError(t, err)
+
+	deadline, ok := streamCtx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+	require.NoError(t, drain(stream))
+	assert.ErrorIs(t, streamCtx.Err(), context.Canceled, "ctx is released once the stream ends")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err = interceptor(ctx, &grpc.StreamDesc{}, nil, "/page.Page/StreamWidgets", streamer)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, streamCtx, "deadline of the caller is kept")
+}
+
+type fakeReceiver struct {
+	items []string
+	err   error
+}
+
+func (r *fakeReceiver) Recv() (string, error) {
+	if len(r.items) == 0 {
+		if r.err != nil {
+			return "", r.err
+		}
+
+		return "", io.EOF
+	}
+
+	item := r.items[0]
+	r.items = r.items[1:]
+
+	return item, nil
+}
+
+func TestCollectStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []string
+		streamErr error
+		maxItems  int
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:  "every item is collected",
+			items: []string{"a", "b", "c"},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:     "stream with as many items as allowed",
+			items:    []string{"a", "b"},
+			maxItems: 2,
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "stream with more items than allowed",
+			items:    []string{"a", "b", "c"},
+			maxItems: 2,
+			want:     []string{"a", "b"},
+			wantErr:  ErrStreamTooLong,
+		},
+		{
+			name:      "stream which fails",
+			items:     []string{"a"},
+			streamErr: status.Error(codes.Unavailable, "down"),
+			want:      []string{"a"},
+			wantErr:   status.Error(codes.Unavailable, "down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", http.NoBody), httptest.NewRecorder())
+
+			var streamCtx context.Context
+			got, err := CollectStream(c, time.Second, tt.maxItems, func(ctx context.Context) (StreamReceiver[string], error) {
+				streamCtx = ctx
+				return &fakeReceiver{items: tt.items, err: tt.streamErr}, nil
+			})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			_, ok := streamCtx.Deadline()
+			assert.True(t, ok)
+			assert.Error(t, streamCtx.Err(), "stream is released")
+		})
+	}
+}
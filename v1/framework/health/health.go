@@ -0,0 +1,99 @@
+// Package health serves the liveness and readiness endpoints of a bff service.
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/dynamicconfig"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+const (
+	LivePath  = "/health/live"
+	ReadyPath = "/health/ready"
+
+	// GRPCProbeFlagKey enables the gRPC health protocol probes of the downstreams in readiness checks
+	GRPCProbeFlagKey = "health.grpc_probe_enabled"
+
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+
+	dependencyTypeGRPC   = "grpc"
+	dependencyTypeConfig = "config"
+	dynamicConfigName    = "dynamic-config"
+)
+
+// Report is the JSON response of the health endpoints
+type Report struct {
+	Status       string       `json:"status"`
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+type Dependency struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	State  string `json:"state,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Handler struct {
+	conns  ConnHealthChecker
+	cnf    *config.Config
+	logger logger.Logger
+}
+
+func NewHandler(conns ConnHealthChecker, cfg *config.Config, log logger.Logger) *Handler {
+	return &Handler{conns: conns, cnf: cfg, logger: log}
+}
+
+// Live reports that the service is up, it does not check any dependency so that a failing downstream does not restart
+// the pods
+func (h *Handler) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, Report{Status: StatusUp})
+}
+
+// Ready reports whether the service can serve traffic: every downstream connection must be usable and dynamic config
+// must be initialised when it is configured. It responds with http.StatusServiceUnavailable when any dependency is down.
+func (h *Handler) Ready(c echo.Context) error {
+	probe := dynamicconfig.GetFeatureFlag(c, h.logger, h.cnf.DynamicConfig, GRPCProbeFlagKey)
+
+	report := Report{Status: StatusUp}
+
+	if h.cnf.AppConfig.AppID != "" {
+		dependency := Dependency{Name: dynamicConfigName, Type: dependencyTypeConfig, Status: StatusUp}
+		if h.cnf.DynamicConfig == nil {
+			dependency.Status = StatusDown
+			dependency.Error = "dynamic config is not initialised"
+		}
+
+		report.add(dependency)
+	}
+
+	for _, conn := range h.conns.CheckConnections(c.Request().Context(), probe) {
+		dependency := Dependency{Name: conn.Client, Type: dependencyTypeGRPC, Status: StatusUp, State: conn.State, Error: conn.Error}
+		if !conn.Healthy {
+			dependency.Status = StatusDown
+		}
+
+		report.add(dependency)
+	}
+
+	if report.Status != StatusUp {
+		h.logger.WithContext(c).Warnf("service is not ready, report: %+v", report)
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+func (r *Report) add(dependency Dependency) {
+	if dependency.Status != StatusUp {
+		r.Status = StatusDown
+	}
+
+	r.Dependencies = append(r.Dependencies, dependency)
+}
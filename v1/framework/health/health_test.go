@@ -0,0 +1,92 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+func TestHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		cnf        *config.Config
+		conns      []grpc.ConnHealth
+		wantCode   int
+		wantStatus string
+		wantDeps   []Dependency
+	}{
+		{
+			name:       "ready when every connection is healthy",
+			cnf:        &config.Config{},
+			conns:      []grpc.ConnHealth{{Client: "page-client", State: "READY", Healthy: true}},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusUp,
+			wantDeps:   []Dependency{{Name: "page-client", Type: dependencyTypeGRPC, Status: StatusUp, State: "READY"}},
+		},
+		{
+			name: "not ready when a connection is in transient failure",
+			cnf:  &config.Config{},
+			conns: []grpc.ConnHealth{
+				{Client: "page-client", State: "TRANSIENT_FAILURE", Error: "connection is in TRANSIENT_FAILURE"},
+				{Client: "user-client", State: "IDLE", Healthy: true},
+			},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusDown,
+			wantDeps: []Dependency{
+				{Name: "page-client", Type: dependencyTypeGRPC, Status: StatusDown, State: "TRANSIENT_FAILURE", Error: "connection is in TRANSIENT_FAILURE"},
+				{Name: "user-client", Type: dependencyTypeGRPC, Status: StatusUp, State: "IDLE"},
+			},
+		},
+		{
+			name:       "not ready when dynamic config is not initialised",
+			cnf:        &config.Config{AppConfig: config.AppConfig{AppID: "bff"}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusDown,
+			wantDeps: []Dependency{
+				{Name: dynamicConfigName, Type: dependencyTypeConfig, Status: StatusDown, Error: "dynamic config is not initialised"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			conns := NewMockConnHealthChecker(ctrl)
+			conns.EXPECT().CheckConnections(gomock.Any(), false).Return(tt.conns)
+
+			var log logger.Logger = logger.NewAPILogger(&config.Config{Logger: config.Logger{Level: "error"}})
+			log.InitLogger()
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, ReadyPath, http.NoBody), rec)
+
+			require.NoError(t, NewHandler(conns, tt.cnf, log).Ready(c))
+
+			var report Report
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantDeps, report.Dependencies)
+		})
+	}
+}
+
+func TestHandler_Live(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, LivePath, http.NoBody), rec)
+
+	require.NoError(t, NewHandler(nil, &config.Config{}, nil).Live(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"UP"}`, rec.Body.String())
+}
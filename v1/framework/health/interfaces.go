@@ -0,0 +1,11 @@
+package health
+
+import (
+	"context"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+)
+
+type ConnHealthChecker interface {
+	CheckConnections(ctx context.Context, probe bool) []grpc.ConnHealth
+}
@@ -0,0 +1,152 @@
+// Package lifecycle shuts a bff service down gracefully, so that a deploy neither fails the requests in flight nor
+// loses their telemetry.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+// DefaultShutdownTimeout is the time given to a service to shut down, below the default termination grace period of
+// kubernetes
+const DefaultShutdownTimeout = 25 * time.Second
+
+// ErrShuttingDown is returned for the requests received while the service is shutting down
+var ErrShuttingDown = echo.NewHTTPError(http.StatusServiceUnavailable, "service is shutting down")
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Lifecycle tracks the requests in flight of an echo server and shuts it down in order: it stops accepting requests,
+// waits for the requests in flight, and then runs the shutdown hooks in the order they are registered. The grpc
+// connections are usually closed before the telemetry is flushed:
+//
+//	lc := lifecycle.New(e, log)
+//	lc.OnShutdown("grpc connections", func(context.Context) error { return grpcHandler.Close() })
+//	lc.OnShutdown("telemetry", otelHandler.Shutdown)
+type Lifecycle struct {
+	server   *echo.Echo
+	logger   logger.Logger
+	inFlight sync.WaitGroup
+	draining atomic.Bool
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+// New returns the lifecycle of the echo server, it registers the middleware which tracks the requests in flight
+func New(e *echo.Echo, log logger.Logger) *Lifecycle {
+	l := &Lifecycle{server: e, logger: log}
+	e.Use(l.track)
+
+	return l
+}
+
+// OnShutdown registers a hook which is run on shutdown once the requests in flight are done
+func (l *Lifecycle) OnShutdown(name string, fn func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hooks = append(l.hooks, hook{name: name, fn: fn})
+}
+
+// track counts the requests in flight, and rejects the requests received once the shutdown started
+func (l *Lifecycle) track(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if l.draining.Load() {
+			return ErrShuttingDown
+		}
+
+		l.inFlight.Add(1)
+		defer l.inFlight.Done()
+
+		return next(c)
+	}
+}
+
+// Shutdown stops the server from accepting requests, waits for the requests in flight and then runs the shutdown
+// hooks. Every hook is run even when the ctx is done or a previous step failed, their errors are joined.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.draining.Store(true)
+	l.logger.Infof("shutting down, waiting for the requests in flight")
+
+	var errs []error
+
+	if err := l.server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error shutting down server: %w", err))
+	}
+
+	if err := l.wait(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error waiting for requests in flight: %w", err))
+	}
+
+	l.mu.Lock()
+	hooks := l.hooks
+	l.mu.Unlock()
+
+	for _, h := range hooks {
+		if err := h.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error running shutdown hook %s: %w", h.name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		l.logger.Errorf("error shutting down, err: %v", err)
+		return err
+	}
+
+	l.logger.Infof("shut down gracefully")
+
+	return nil
+}
+
+// wait waits for the requests in flight until the ctx is done
+func (l *Lifecycle) wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		l.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ShutdownOnSignal blocks until the process receives SIGTERM or SIGINT and then shuts down within the timeout. It is
+// meant to be called from main once the server is started:
+//
+//	go func() { _ = e.Start(addr) }()
+//	if err := lc.ShutdownOnSignal(lifecycle.DefaultShutdownTimeout); err != nil { ... }
+func (l *Lifecycle) ShutdownOnSignal(timeout time.Duration) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+
+	defer signal.Stop(signals)
+
+	sig := <-signals
+	l.logger.Infof("received signal %v", sig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return l.Shutdown(ctx)
+}
@@ -0,0 +1,133 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+func newTestLifecycle() (*echo.Echo, *Lifecycle) {
+	var log logger.Logger = logger.NewAPILogger(&config.Config{Logger: config.Logger{Level: "error"}})
+	log.InitLogger()
+
+	e := echo.New()
+
+	return e, New(e, log)
+}
+
+func TestLifecycle_Shutdown(t *testing.T) {
+	e, lc := newTestLifecycle()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		<-release
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	var order []string
+	lc.OnShutdown("grpc connections", func(context.Context) error {
+		order = append(order, "grpc connections")
+		return nil
+	})
+	lc.OnShutdown("telemetry", func(context.Context) error {
+		order = append(order, "telemetry")
+		return errors.New("flush failed")
+	})
+
+	slow := httptest.NewRecorder()
+	served := make(chan struct{})
+
+	go func() {
+		e.ServeHTTP(slow, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		close(served)
+	}()
+	<-started
+
+	shutdownErr := make(chan error)
+	go func() { shutdownErr <- lc.Shutdown(context.Background()) }()
+
+	// the hooks wait for the request in flight, and new requests are rejected meanwhile
+	assert.Eventually(t, lc.draining.Load, time.Second, time.Millisecond)
+
+	rejected := httptest.NewRecorder()
+	e.ServeHTTP(rejected, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rejected.Code)
+	assert.Empty(t, order)
+
+	close(release)
+	<-served
+
+	err := <-shutdownErr
+	assert.ErrorContains(t, err, "telemetry")
+	assert.Equal(t, http.StatusOK, slow.Code)
+	assert.Equal(t, []string{"grpc connections", "telemetry"}, order)
+}
+
+func TestLifecycle_ShutdownTimeout(t *testing.T) {
+	e, lc := newTestLifecycle()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+
+	e.GET("/stuck", func(c echo.Context) error {
+		close(started)
+		<-release
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	hookRun := false
+	lc.OnShutdown("telemetry", func(context.Context) error {
+		hookRun = true
+		return nil
+	})
+
+	go e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stuck", nil))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := lc.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, hookRun, "hooks run even when the requests in flight are not done in time")
+}
+
+func TestLifecycle_ShutdownOnSignal(t *testing.T) {
+	_, lc := newTestLifecycle()
+
+	hookRun := make(chan struct{})
+	lc.OnShutdown("telemetry", func(context.Context) error {
+		close(hookRun)
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- lc.ShutdownOnSignal(time.Second) }()
+
+	// wait for the signal handler to be registered before sending the signal
+	require.Eventually(t, func() bool {
+		select {
+		case <-hookRun:
+			return true
+		default:
+			_ = syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, <-done)
+}
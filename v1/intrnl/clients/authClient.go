@@ -23,16 +23,20 @@ func (cm *ClientManager) GetAuthClient(c echo.Context) (auth.AuthenticationClien
 }
 
 func (cm *ClientManager) RefreshToken(c echo.Context, cnf *config.Config, req *authReq.RefreshTokenRequest) (*authRes.RefreshTokenResponse, error) {
-	authClient, err := cm.GetAuthClient(c)
+	conf := config.GetClientConfigs(clients.AuthenticationServiceClient, cnf)
+
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
 	if err != nil {
+		cm.logger.WithContext(c).Errorf("skipping call to authentication service, err: %v", err)
 		return nil, err
 	}
-
-	conf := config.GetClientConfigs(clients.AuthenticationServiceClient, cnf)
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
-
 	defer apiCancel()
 
+	authClient, err := cm.GetAuthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
 	sores, err := authClient.RefreshTokenAuthentication(apiCtx, req)
 	if err != nil {
 		cm.logger.WithContext(c).Error(err)
@@ -26,16 +26,20 @@ func (cm *ClientManager) getAuthzPdpClient(c echo.Context, _ *config.Config) (au
 func (cm *ClientManager) EnforceRbac(c echo.Context, cnf *config.Config, resource types.ResourceTypes, action types.Action) (*response.GetDecisionResponse, error) {
 	cm.logger.WithContext(c).Infof("EnforceRbac: Request with resource: %v, action: %v", resource.String(), action.String())
 
-	authZClient, err := cm.getAuthzPdpClient(c, cnf)
+	conf := config.GetClientConfigs(clients.AuthorizationPdpServiceClient, cnf)
+
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
 	if err != nil {
+		cm.logger.WithContext(c).Errorf("skipping call to authorization pdp service, err: %v", err)
 		return nil, err
 	}
-
-	conf := config.GetClientConfigs(clients.AuthorizationPdpServiceClient, cnf)
-
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
 	defer apiCancel()
 
+	authZClient, err := cm.getAuthzPdpClient(c, cnf)
+	if err != nil {
+		return nil, err
+	}
+
 	tenantID, err := internal.GetTenantID(c)
 	if err != nil {
 		return nil, err
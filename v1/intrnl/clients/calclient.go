@@ -64,18 +64,21 @@ func (cm *ClientManager) getPlaylistFileName(c echo.Context, cnf *config.Config)
 }
 
 func (cm *ClientManager) GetLearningMaterial(c echo.Context, cnf *config.Config, req *request.GetLearningMaterialRequest) (*response.GetLearningMaterialResponse, error) {
-	calClient, err := cm.getCalClient(c)
+	conf := config.GetClientConfigs(clients.CalServiceClient, cnf)
 
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
 	if err != nil {
-		cm.logger.WithContext(c).Error(err)
+		cm.logger.WithContext(c).Errorf("skipping call to cal service, err: %v", err)
 		return nil, err
 	}
-
-	conf := config.GetClientConfigs(clients.CalServiceClient, cnf)
-
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
 	defer apiCancel()
 
+	calClient, err := cm.getCalClient(c)
+	if err != nil {
+		cm.logger.WithContext(c).Error(err)
+		return nil, err
+	}
+
 	resp, err := calClient.GetLearningMaterial(apiCtx, req)
 	if err != nil {
 		cm.logger.WithContext(c).Error(err)
@@ -86,18 +89,21 @@ func (cm *ClientManager) GetLearningMaterial(c echo.Context, cnf *config.Config,
 }
 
 func (cm *ClientManager) GetNAC(c echo.Context, cnf *config.Config, req *request.GetNACRequest) (*response.GetNACResponse, error) {
-	calClient, err := cm.getCalClient(c)
+	conf := config.GetClientConfigs(clients.CalServiceClient, cnf)
 
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
 	if err != nil {
-		cm.logger.WithContext(c).Error(err)
+		cm.logger.WithContext(c).Errorf("skipping call to cal service, err: %v", err)
 		return nil, err
 	}
-
-	conf := config.GetClientConfigs(clients.CalServiceClient, cnf)
-
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
 	defer apiCancel()
 
+	calClient, err := cm.getCalClient(c)
+	if err != nil {
+		cm.logger.WithContext(c).Error(err)
+		return nil, err
+	}
+
 	resp, err := calClient.GetNAC(apiCtx, req)
 	if err != nil {
 		cm.logger.WithContext(c).Error(err)
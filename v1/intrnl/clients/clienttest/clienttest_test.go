@@ -0,0 +1,94 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	clients "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/clients/constants"
+)
+
+func TestManager_GetConn(t *testing.T) {
+	m := NewManager(t)
+
+	for _, client := range []string{
+		clients.AuthenticationServiceClient,
+		clients.AuthorizationPdpServiceClient,
+		clients.UserServiceClient,
+		clients.ResourceServiceClient,
+		clients.CalServiceClient,
+		clients.PageServiceClient,
+	} {
+		conn, err := m.GetConn(nil, nil, client, nil)
+		require.NoError(t, err, client)
+
+		s, ok := m.Server(client)
+		require.True(t, ok, client)
+		assert.Same(t, s.Conn(), conn, client)
+	}
+
+	_, err := m.GetConn(nil, nil, "unknownServiceConfig", nil)
+	assert.Error(t, err)
+}
+
+func TestServer(t *testing.T) {
+	m := NewManager(t)
+	conn, err := m.GetConn(nil, nil, clients.UserServiceClient, nil)
+	require.NoError(t, err)
+
+	client := healthpb.NewHealthClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-user-id", "42")
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "user"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	m.User.Respond("Check", &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	m.User.Fail("/grpc.health.v1.Health/Check", status.Error(codes.NotFound, "user not found"))
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.User.Handle("Check", func(_ context.Context, req Request) (proto.Message, error) {
+		in := &healthpb.HealthCheckRequest{}
+		if err := req.Decode(in); err != nil {
+			return nil, err
+		}
+
+		if in.GetService() != "user" {
+			return nil, errors.New("unexpected service")
+		}
+
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	})
+
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	m.User.AssertCalled(t, "Check", 4)
+	m.Cal.AssertCalled(t, "", 0)
+
+	last := &healthpb.HealthCheckRequest{}
+	m.User.LastCall(t, "Check", last)
+	assert.Equal(t, "user", last.GetService())
+	assert.Equal(t, []string{"42"}, m.User.Calls("Check")[0].Metadata.Get("x-user-id"))
+
+	m.User.Reset()
+	m.User.AssertCalled(t, "", 0)
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,75 @@
+package clienttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	googleGRPC "google.golang.org/grpc"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+	clients "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/clients/constants"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+// Manager is a grpc.Manager which connects every built-in client to its fake server:
+//
+//	fakes := clienttest.NewManager(t)
+//	fakes.User.Respond("GetUser", &userRes.GetUserResponse{...})
+//	cm := clients.NewClientManager(cnf, log, fakes)
+//	...
+//	fakes.User.AssertCalled(t, "GetUser", 1)
+type Manager struct {
+	Authentication   *Server
+	AuthorizationPdp *Server
+	User             *Server
+	Resource         *Server
+	Cal              *Server
+	Page             *Server
+
+	servers map[string]*Server
+}
+
+var _ grpc.Manager = (*Manager)(nil)
+
+// NewManager starts a fake server for every built-in client, they are stopped when the test ends
+func NewManager(t testing.TB) *Manager {
+	t.Helper()
+
+	m := &Manager{
+		Authentication:   NewServer(t),
+		AuthorizationPdp: NewServer(t),
+		User:             NewServer(t),
+		Resource:         NewServer(t),
+		Cal:              NewServer(t),
+		Page:             NewServer(t),
+	}
+
+	m.servers = map[string]*Server{
+		clients.AuthenticationServiceClient:   m.Authentication,
+		clients.AuthorizationPdpServiceClient: m.AuthorizationPdp,
+		clients.UserServiceClient:             m.User,
+		clients.ResourceServiceClient:         m.Resource,
+		clients.CalServiceClient:              m.Cal,
+		clients.PageServiceClient:             m.Page,
+	}
+
+	return m
+}
+
+// Server returns the fake server of the client
+func (m *Manager) Server(client string) (*Server, bool) {
+	s, ok := m.servers[client]
+	return s, ok
+}
+
+// GetConn returns the connection to the fake server of the client, it fails for clients which are not built-in
+func (m *Manager) GetConn(_ echo.Context, _ logger.Logger, client string, _ *config.Config) (googleGRPC.ClientConnInterface, error) {
+	s, ok := m.servers[client]
+	if !ok {
+		return nil, fmt.Errorf("no fake grpc server for client: %s", client)
+	}
+
+	return s.Conn(), nil
+}
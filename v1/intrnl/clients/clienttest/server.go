@@ -0,0 +1,236 @@
+// Package clienttest starts in-process fake grpc servers for the downstream services of the ClientManager, so that
+// tests can program the responses of the downstream and assert the calls it receives instead of mocking every GetConn.
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const bufSize = 1024 * 1024
+
+// Handler answers a call to a fake server
+type Handler func(ctx context.Context, req Request) (proto.Message, error)
+
+// Request is a call received by a fake server
+type Request struct {
+	Method   string      // fully-qualified grpc method, e.g. /package.Service/Method
+	Metadata metadata.MD // incoming metadata of the call
+	raw      []byte
+}
+
+// Decode decodes the request message of the call into msg
+func (r Request) Decode(msg proto.Message) error {
+	return proto.Unmarshal(r.raw, msg)
+}
+
+// Server is a fake grpc server listening on an in-memory connection. It serves every unary method of every service:
+// methods are programmed with Respond, Fail or Handle by their fully-qualified or short name, and calls to the
+// methods which are not programmed fail with codes.Unimplemented.
+type Server struct {
+	conn *grpc.ClientConn
+
+	mu       sync.Mutex
+	handlers []namedHandler
+	calls    []Request
+}
+
+type namedHandler struct {
+	method  string
+	handler Handler
+}
+
+// NewServer starts a fake server, it is stopped when the test ends
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{}
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(s.serve))
+
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("error dialing fake grpc server: %v", err)
+	}
+
+	s.conn = conn
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Stop()
+	})
+
+	return s
+}
+
+// Conn returns the connection to the server
+func (s *Server) Conn() *grpc.ClientConn {
+	return s.conn
+}
+
+// Handle programs the method to be answered by the handler, it overrides the earlier programming of the method
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = append(s.handlers, namedHandler{method: method, handler: handler})
+}
+
+// Respond programs the method to answer every call with the response
+func (s *Server) Respond(method string, resp proto.Message) {
+	s.Handle(method, func(context.Context, Request) (proto.Message, error) {
+		return resp, nil
+	})
+}
+
+// Fail programs the method to fail every call with the error, errors without a grpc status fail with codes.Unknown
+func (s *Server) Fail(method string, err error) {
+	s.Handle(method, func(context.Context, Request) (proto.Message, error) {
+		return nil, err
+	})
+}
+
+// Calls returns the calls received for the method, every call is returned when the method is empty
+func (s *Server) Calls(method string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var calls []Request
+
+	for _, call := range s.calls {
+		if method == "" || matchesMethod(method, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// AssertCalled fails the test unless the method received the number of calls
+func (s *Server) AssertCalled(t testing.TB, method string, times int) {
+	t.Helper()
+
+	if got := len(s.Calls(method)); got != times {
+		t.Errorf("fake grpc server: %s called %d times, want %d", method, got, times)
+	}
+}
+
+// LastCall decodes the request message of the last call to the method into msg, it fails the test when the method was
+// not called
+func (s *Server) LastCall(t testing.TB, method string, msg proto.Message) {
+	t.Helper()
+
+	calls := s.Calls(method)
+	if len(calls) == 0 {
+		t.Fatalf("fake grpc server: %s not called", method)
+	}
+
+	if err := calls[len(calls)-1].Decode(msg); err != nil {
+		t.Fatalf("fake grpc server: error decoding request of %s: %v", method, err)
+	}
+}
+
+// Reset forgets the programmed methods and the received calls
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = nil
+	s.calls = nil
+}
+
+func (s *Server) handler(method string) (Handler, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.handlers) - 1; i >= 0; i-- {
+		if matchesMethod(s.handlers[i].method, method) {
+			return s.handlers[i].handler, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *Server) serve(_ any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+
+	var raw rawMessage
+	if err := stream.RecvMsg(&raw); err != nil {
+		return err
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	req := Request{Method: method, Metadata: md, raw: raw}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, req)
+	s.mu.Unlock()
+
+	handler, ok := s.handler(method)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "fake grpc server: %s is not programmed", method)
+	}
+
+	resp, err := handler(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendMsg(resp)
+}
+
+// matchesMethod reports whether the programmed method name, fully-qualified or short, names the method
+func matchesMethod(name, method string) bool {
+	return name == method || strings.HasSuffix(method, "/"+strings.TrimPrefix(name, "/"))
+}
+
+// rawMessage is the undecoded request of a call, the fake servers do not know the message types of the services
+type rawMessage []byte
+
+// rawCodec receives requests as raw bytes and sends proto responses
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("fake grpc server: %T is not a proto message", v)
+	}
+
+	return proto.Marshal(msg)
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	raw, ok := v.(*rawMessage)
+	if !ok {
+		return fmt.Errorf("fake grpc server: unexpected message %T", v)
+	}
+
+	*raw = append((*raw)[:0], data...)
+
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+var _ encoding.Codec = rawCodec{}
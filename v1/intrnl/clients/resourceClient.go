@@ -25,16 +25,21 @@ func (cm *ClientManager) getGRPCConn(c echo.Context) (grpcclient.ClientConnInter
 func (cm *ClientManager) GetStudentBatchDetails(c echo.Context, _ *config.Config, request *pbrq.GetStudentBatchDetailsRequest) (*pbrs.GetStudentBatchDetailsResponse, error) {
 	cm.logger.WithContext(c).Infof("calling resource service ::GetStudentBatchDetails, request :: %s ", request)
 
+	conf := config.GetClientConfigs(clients.ResourceServiceClient, cm.cfg)
+
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
+	if err != nil {
+		cm.logger.WithContext(c).Errorf("skipping call to resource service, err: %v", err)
+		return nil, err
+	}
+	defer apiCancel()
+
 	conn, err := cm.getGRPCConn(c)
 	if err != nil {
 		return nil, err
 	}
 
 	batchMappingClient := pb.NewStudentBatchMappingClient(conn)
-	conf := config.GetClientConfigs(clients.ResourceServiceClient, cm.cfg)
-
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
-	defer apiCancel()
 
 	response, err := batchMappingClient.GetStudentBatchDetails(apiCtx, request)
 	if err != nil {
@@ -50,16 +55,21 @@ func (cm *ClientManager) GetStudentBatchDetails(c echo.Context, _ *config.Config
 func (cm *ClientManager) GetAncestorsOfAFacility(c echo.Context, request *pbrq.GetAncestorsOfAFacilityRequest) (*pbrs.GetAncestorsOfAFacilityResponse, error) {
 	cm.logger.WithContext(c).Infof("calling resource service :: get ancestors of a facilities, request :: %s ", request)
 
+	conf := config.GetClientConfigs(clients.ResourceServiceClient, cm.cfg)
+
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
+	if err != nil {
+		cm.logger.WithContext(c).Errorf("skipping call to resource service, err: %v", err)
+		return nil, err
+	}
+	defer apiCancel()
+
 	conn, err := cm.getGRPCConn(c)
 	if err != nil {
 		return nil, err
 	}
 
 	facilityClient := pb.NewFacilityClient(conn)
-	conf := config.GetClientConfigs(clients.ResourceServiceClient, cm.cfg)
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
-
-	defer apiCancel()
 
 	response, err := facilityClient.GetAncestorsOfAFacility(apiCtx, request)
 	if err != nil {
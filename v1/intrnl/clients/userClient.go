@@ -61,16 +61,20 @@ func (cm *ClientManager) GetUser(c echo.Context, cnf *config.Config, grpcHandler
 		UserId:   uid,
 	}
 
-	userClient, err := cm.GetUserServiceClient(c)
+	conf := config.GetClientConfigs(clients.UserServiceClient, cnf)
+
+	apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
 	if err != nil {
+		cm.logger.WithContext(c).Errorf("skipping call to user service, err: %v", err)
 		return nil, err
 	}
-
-	conf := config.GetClientConfigs(clients.UserServiceClient, cnf)
-
-	apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
 	defer apiCancel()
 
+	userClient, err := cm.GetUserServiceClient(c)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := userClient.GetUser(apiCtx, request)
 	if err != nil {
 		cm.logger.WithContext(c).Errorf("error while calling user service for user: %v and tenant: %v", uid, tenant)
@@ -354,8 +354,12 @@ func (*ResponseMapper) addTrackingParamsData(c echo.Context) *structpb.Struct {
 
 }
 
-// TODO :Handle data inside TAB as List page resp
+// mapTabPageInfoToTabInfo maps the tab page meta only, PageData of selected tabs (including nested tab pages)
+// is resolved by the page handler once the datasources of the tab page are processed
 func (*ResponseMapper) mapTabPageInfoToTabInfo(tpi *pbTypes.TabPageInfo) *pageResp.TabInfo {
+	if tpi == nil {
+		return nil
+	}
 	ti := &pageResp.TabInfo{}
 	ti.PageID = tpi.PageId
 	ti.URL = tpi.Url
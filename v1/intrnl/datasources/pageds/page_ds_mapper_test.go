@@ -456,6 +456,11 @@ func TestMapTabPageInfoToTabInfo(t *testing.T) {
 				TabAction: nil,
 			},
 		},
+		{
+			name:           "Nil TabPageInfo",
+			input:          nil,
+			expectedOutput: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -25,3 +25,9 @@ const (
 	BatchCodeContextCriteriaParam        = "batch-code"
 	FacilityCodeContextCriteriaParam     = "facility-code"
 )
+
+const (
+	// NestedTabMaxDepthKey dynamic config key for the maximum depth of pages resolved inside nested tabs
+	NestedTabMaxDepthKey     = "page.nested_tab_max_depth"
+	DefaultNestedTabMaxDepth = 3
+)
@@ -19,6 +19,7 @@ import (
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/clients"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/datasources"
 	pageds2 "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/datasources/pageds"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/dynamicconfig"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/models/lmm"
 	internalUtils "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/utils"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/httpwrapper"
@@ -121,6 +122,15 @@ func (pdh *pageDataHandler) GetPage() datasource.HandlerFunc {
 
 		pdh.handleQueryParams(c, gpr.PageURL, userContext)
 
+		conf := config.GetClientConfigs(grpcClients.PageServiceClient, cnf)
+		apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
+		if err != nil {
+			pdh.logger.WithContext(c).Errorf("skipping call to page service, URL: %s, err: %v", gpr.PageURL, err)
+			code, msg := utils.HandleError(c, err, pdh.logger)
+			return intrnl.PopulateResponse(code, UserFacingMessage(code), msg), nil
+		}
+		defer apiCancel()
+
 		// create page service req
 		pageClient, err := pdh.getPageServiceClient(c, cnf)
 		if err != nil {
@@ -128,9 +138,6 @@ func (pdh *pageDataHandler) GetPage() datasource.HandlerFunc {
 		}
 
 		request := getPageRequest(c, gpr)
-		conf := config.GetClientConfigs(grpcClients.PageServiceClient, cnf)
-		apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
-		defer apiCancel()
 
 		gpResp, err := pageClient.GetPageFromCache(apiCtx, request)
 		if err != nil {
@@ -191,6 +198,15 @@ func (pdh *pageDataHandler) GetPageWithRouting() datasource.HandlerFunc {
 
 		pdh.handleQueryParams(c, gpr.PageURL, userContext)
 
+		conf := config.GetClientConfigs(grpcClients.PageServiceClient, cnf)
+		apiCtx, apiCancel, err := utils.GetCallCtxWithBudget(c, conf.Timeout)
+		if err != nil {
+			pdh.logger.WithContext(c).Errorf("skipping call to page service, URL: %s, err: %v", gpr.PageURL, err)
+			code, msg := utils.HandleError(c, err, pdh.logger)
+			return intrnl.PopulateResponse(code, UserFacingMessage(code), msg), nil
+		}
+		defer apiCancel()
+
 		// create page service req
 		pageClient, err := pdh.getPageServiceClient(c, cnf)
 		if err != nil {
@@ -198,9 +214,6 @@ func (pdh *pageDataHandler) GetPageWithRouting() datasource.HandlerFunc {
 		}
 
 		request := getPageRequest(c, gpr)
-		conf := config.GetClientConfigs(grpcClients.PageServiceClient, cnf)
-		apiCtx, apiCancel := utils.GetRequestCtxWithTimeout(c, conf.Timeout)
-		defer apiCancel()
 
 		gpResp, err := pageClient.GetPageFromCache(apiCtx, request)
 		if err != nil {
@@ -332,15 +345,50 @@ func (pdh *pageDataHandler) handleTabPage(c echo.Context, pInfo *pbTypes.PageInf
 }
 
 func (pdh *pageDataHandler) processTabData(c echo.Context, pInfo *pbTypes.PageInfo, pageResp *page.CommonPageResponse) error {
+	tr := &tabResolution{
+		maxDepth: pdh.getNestedTabMaxDepth(c),
+		visited:  map[string]bool{getPageKey(pInfo): true},
+	}
+	return pdh.processTabDataAtDepth(c, pInfo, pageResp, tr, 0)
+}
+
+func (pdh *pageDataHandler) processTabDataAtDepth(c echo.Context, pInfo *pbTypes.PageInfo, pageResp *page.CommonPageResponse, tr *tabResolution, depth int) error {
 	pageResp.TabData = pdh.prm.HandleTabPageResponse(c, pInfo)
 	if len(pInfo.TabData) == 0 || len(pageResp.TabData) == 0 {
 		return ErrorNoTabsToShow
 	}
 	// Now resolving page inside tabs for all selected tabs, resolving here to avoid cyclic dependency
-	pdh.resolvePageWithinTabs(c, pInfo.TabData, pageResp)
+	pdh.resolvePageWithinTabs(c, pInfo.TabData, pageResp, tr, depth+1)
 	return nil
 }
 
+// tabResolution holds the state shared by every level of a nested tab page while it is being resolved
+type tabResolution struct {
+	maxDepth int
+	// visited contains the pages on the current path from the root page, used to detect cycles
+	visited map[string]bool
+}
+
+// getNestedTabMaxDepth returns the maximum depth up to which pages inside tabs are resolved,
+// depth 1 being the pages of the root tab page
+func (pdh *pageDataHandler) getNestedTabMaxDepth(c echo.Context) int {
+	val := dynamicconfig.GetOrDefaultFromAppConfig(pdh.cnf.DynamicConfig, NestedTabMaxDepthKey, strconv.Itoa(DefaultNestedTabMaxDepth))
+	maxDepth, err := strconv.Atoi(val)
+	if err != nil || maxDepth < 1 {
+		pdh.logger.WithContext(c).Warnf("invalid value %s for %s, using default: %d", val, NestedTabMaxDepthKey, DefaultNestedTabMaxDepth)
+		return DefaultNestedTabMaxDepth
+	}
+	return maxDepth
+}
+
+// getPageKey identifies a page for cycle detection, falling back to the numeric id when page_id is not set
+func getPageKey(pInfo *pbTypes.PageInfo) string {
+	if pInfo.GetPageId() != utils.EmptyString {
+		return pInfo.GetPageId()
+	}
+	return strconv.Itoa(int(pInfo.GetId()))
+}
+
 func (pdh *pageDataHandler) processPageDetailsAndWidgetData(c echo.Context, pageInfo *pbTypes.PageInfo) (*page.CommonPageResponse, error) {
 	resolvedWidgetsMap := make(map[string]bool)
 	pageResp, dsNames, wPosList, err := pdh.prm.MapResponse(c, pageInfo, resolvedWidgetsMap)
@@ -423,18 +471,47 @@ func (pdh *pageDataHandler) handleQueryParams(c echo.Context, urlString string,
 	}
 }
 
-func (pdh *pageDataHandler) resolvePageWithinTabs(c echo.Context, tabData []*pbTypes.TabContent, pageResp *page.CommonPageResponse) {
+// resolvePageWithinTabs resolves the pages of all selected tabs, recursing into pages which are tab pages themselves.
+// All levels are resolved on the same echo context, so nested pages reuse the shared_datasource results
+// already fetched by their parents and only preload the datasources which are missing.
+func (pdh *pageDataHandler) resolvePageWithinTabs(c echo.Context, tabData []*pbTypes.TabContent, pageResp *page.CommonPageResponse, tr *tabResolution, depth int) {
 	for i, tab := range tabData {
-		if tab.Selected {
-			if tab.TabInfo != nil && tab.TabInfo.PageData != nil {
-				listPageRes, err := pdh.processPageDetailsAndWidgetData(c, tab.TabInfo.PageData)
-				if err != nil {
-					pdh.logger.WithContext(c).Errorf("could not resolve data for pageID: %v within Tab, err: %v, skipping this page", tab.TabInfo.PageId, err)
-					continue
-				}
-				pageResp.TabData[i].TabInfo.PageData = listPageRes
-			}
+		if !tab.Selected || tab.TabInfo == nil || tab.TabInfo.PageData == nil {
+			continue
+		}
+
+		tabPage := tab.TabInfo.PageData
+		pageKey := getPageKey(tabPage)
+		if tr.visited[pageKey] {
+			pdh.logger.WithContext(c).Errorf("cycle detected for pageID: %v within Tab at depth: %d, skipping this page", tab.TabInfo.PageId, depth)
+			continue
 		}
+
+		listPageRes, err := pdh.processPageDetailsAndWidgetData(c, tabPage)
+		if err != nil {
+			pdh.logger.WithContext(c).Errorf("could not resolve data for pageID: %v within Tab, err: %v, skipping this page", tab.TabInfo.PageId, err)
+			continue
+		}
+
+		if tabPage.GetPageMeta().GetPageType() == pbTypes.PageMeta_TAB {
+			pdh.resolveNestedTabPage(c, tabPage, listPageRes, tr, depth)
+		}
+		pageResp.TabData[i].TabInfo.PageData = listPageRes
+	}
+}
+
+func (pdh *pageDataHandler) resolveNestedTabPage(c echo.Context, tabPage *pbTypes.PageInfo, pageResp *page.CommonPageResponse, tr *tabResolution, depth int) {
+	if depth >= tr.maxDepth {
+		pdh.logger.WithContext(c).Warnf("max nested tab depth: %d reached for pageID: %v, tabs of this page will not be resolved", tr.maxDepth, tabPage.PageId)
+		return
+	}
+
+	pageKey := getPageKey(tabPage)
+	tr.visited[pageKey] = true
+	defer delete(tr.visited, pageKey)
+
+	if err := pdh.processTabDataAtDepth(c, tabPage, pageResp, tr, depth); err != nil {
+		pdh.logger.WithContext(c).Errorf("could not resolve tabs for nested pageID: %v, err: %v", tabPage.PageId, err)
 	}
 }
 
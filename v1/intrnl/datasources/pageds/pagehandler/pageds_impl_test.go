@@ -0,0 +1,108 @@
+package pagehandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pbTypes "github.com/Allen-Career-Institute/common-protos/page_service/v1/types"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework"
+	pageds2 "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/datasources/pageds"
+	log "github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPageDataHandler(t *testing.T, cnf *config.Config) (*pageDataHandler, echo.Context) {
+	ctrl := gomock.NewController(t)
+
+	var l log.Logger = log.NewAPILogger(&config.Config{Logger: config.Logger{Level: "info"}})
+	l.InitLogger()
+
+	pdh := &pageDataHandler{
+		cnf:    *cnf,
+		dsm:    framework.NewMockDatasourceMappingsManager(ctrl),
+		logger: l,
+		prm:    *pageds2.NewResponseMapper(&l),
+		eutil:  utils.NewEchoUtil(l),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	return pdh, c
+}
+
+func tabPage(pageID string, pageType pbTypes.PageMeta_PageType, tabs ...*pbTypes.PageInfo) *pbTypes.PageInfo {
+	pInfo := &pbTypes.PageInfo{
+		PageId:          pageID,
+		PageMeta:        &pbTypes.PageMeta{PageType: pageType},
+		PageContentData: &pbTypes.PageContentData{},
+	}
+	for _, tp := range tabs {
+		pInfo.TabData = append(pInfo.TabData, &pbTypes.TabContent{
+			Selected: true,
+			TabInfo:  &pbTypes.TabPageInfo{PageId: tp.PageId, PageData: tp},
+		})
+	}
+	return pInfo
+}
+
+func TestProcessTabDataNestedTabs(t *testing.T) {
+	pdh, c := newTestPageDataHandler(t, &config.Config{})
+
+	leaf := tabPage("leaf", pbTypes.PageMeta_LIST)
+	inner := tabPage("inner", pbTypes.PageMeta_TAB, leaf)
+	root := tabPage("root", pbTypes.PageMeta_TAB, inner)
+
+	pageResp, err := pdh.processPageDetailsAndWidgetData(c, root)
+	assert.NoError(t, err)
+	assert.NoError(t, pdh.processTabData(c, root, pageResp))
+
+	innerResp := pageResp.TabData[0].TabInfo.PageData
+	if assert.NotNil(t, innerResp) && assert.Len(t, innerResp.TabData, 1) {
+		assert.Equal(t, "leaf", innerResp.TabData[0].TabInfo.PageData.PageInfo.PageID)
+	}
+}
+
+func TestProcessTabDataCycle(t *testing.T) {
+	pdh, c := newTestPageDataHandler(t, &config.Config{})
+
+	inner := tabPage("inner", pbTypes.PageMeta_TAB)
+	root := tabPage("root", pbTypes.PageMeta_TAB, inner)
+	// inner tab page points back to the root page
+	inner.TabData = tabPage("", pbTypes.PageMeta_TAB, root).TabData
+
+	pageResp, err := pdh.processPageDetailsAndWidgetData(c, root)
+	assert.NoError(t, err)
+	assert.NoError(t, pdh.processTabData(c, root, pageResp))
+
+	innerResp := pageResp.TabData[0].TabInfo.PageData
+	if assert.NotNil(t, innerResp) && assert.Len(t, innerResp.TabData, 1) {
+		assert.Nil(t, innerResp.TabData[0].TabInfo.PageData)
+	}
+}
+
+func TestProcessTabDataMaxDepth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dc := config.NewMockDynamicConfig(ctrl)
+	dc.EXPECT().Get(NestedTabMaxDepthKey).Return("1", nil)
+
+	pdh, c := newTestPageDataHandler(t, &config.Config{DynamicConfig: dc})
+
+	leaf := tabPage("leaf", pbTypes.PageMeta_LIST)
+	inner := tabPage("inner", pbTypes.PageMeta_TAB, leaf)
+	root := tabPage("root", pbTypes.PageMeta_TAB, inner)
+
+	pageResp, err := pdh.processPageDetailsAndWidgetData(c, root)
+	assert.NoError(t, err)
+	assert.NoError(t, pdh.processTabData(c, root, pageResp))
+
+	innerResp := pageResp.TabData[0].TabInfo.PageData
+	if assert.NotNil(t, innerResp) {
+		assert.Empty(t, innerResp.TabData)
+	}
+}
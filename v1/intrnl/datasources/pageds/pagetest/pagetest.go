@@ -0,0 +1,181 @@
+// Package pagetest runs the page pipeline (mapping, preload, scatter-gather, visibility and tabs) offline,
+// against a fake page service, and compares the page response with a golden file.
+//
+// Golden files are (re)written by running the tests with the update flag:
+//
+//	go test ./... -args -pagetest.update
+package pagetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Allen-Career-Institute/common-protos/page_service/v1/response"
+	pbTypes "github.com/Allen-Career-Institute/common-protos/page_service/v1/types"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	googleGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/datasource"
+	commonModels "github.com/Allen-Career-Institute/go-bff-commons/v1/framework/models/commons"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/datasources/pageds/pagehandler"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/models/page"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+//nolint:gochecknoglobals // test flag shared by every golden file test
+var update = flag.Bool("pagetest.update", false, "update page golden files")
+
+const (
+	goldenFilePerm = 0o600
+	goldenDirPerm  = 0o755
+)
+
+// DataSource is a fake datasource registered by name for a test case
+type DataSource struct {
+	Handler   datasource.HandlerFunc
+	PreloadDS []string
+}
+
+// Case describes a single page assembly test
+type Case struct {
+	// PageInfo is the path of the protojson fixture of the PageInfo returned by the fake page service
+	PageInfo string
+	// Golden is the path of the golden file the JSON page response is compared with
+	Golden      string
+	PageURL     string
+	DataSources map[string]DataSource
+	UserContext map[string]string
+	Claims      jwt.MapClaims
+	// Config is optional, a config without dynamic config is used when nil
+	Config *config.Config
+}
+
+// StaticDataSource returns a fake datasource which always responds with http.StatusOK and the given data
+func StaticDataSource(data any, preloadDS ...string) DataSource {
+	return DataSource{
+		Handler: func(_ echo.Context, _ *config.Config) (commonModels.DSResponse, error) {
+			return intrnl.PopulateResponse(http.StatusOK, http.StatusText(http.StatusOK), data), nil
+		},
+		PreloadDS: preloadDS,
+	}
+}
+
+// Run executes GetPage for the test case and compares the response with the golden file, the golden file is only
+// written when the tests run with the update flag
+func Run(t *testing.T, tc Case) {
+	t.Helper()
+
+	got := GetPage(t, tc)
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(tc.Golden), goldenDirPerm))
+		require.NoError(t, os.WriteFile(tc.Golden, got, goldenFilePerm))
+
+		return
+	}
+
+	want, err := os.ReadFile(tc.Golden)
+	require.NoError(t, err, "golden file missing, run the test with -pagetest.update to create it")
+	require.JSONEq(t, string(want), string(got))
+}
+
+// GetPage executes GetPage for the test case and returns the indented JSON response
+func GetPage(t *testing.T, tc Case) []byte {
+	t.Helper()
+
+	pInfo := readPageInfo(t, tc.PageInfo)
+
+	cnf := tc.Config
+	if cnf == nil {
+		cnf = &config.Config{Logger: config.Logger{Level: "error"}, GoPool: config.GoPool{MaxConcurrentRoutines: 10}}
+	}
+
+	var log logger.Logger = logger.NewAPILogger(cnf)
+	log.InitLogger()
+
+	dsm := framework.NewDataSourceMappings(log)
+	for name, ds := range tc.DataSources {
+		dsm.RegisterDataSource(datasource.CreateNewDataSource(&commonModels.DataSourceConfig{DsName: name, PreloadDS: ds.PreloadDS}, ds.Handler))
+	}
+
+	meter := noop.NewMeterProvider().Meter("pagetest")
+	handler := pagehandler.NewPageDataHandler(cnf, dsm, &log, meter, *intrnl.NewMapper(meter), &fakePageService{pageInfo: pInfo})
+
+	c := newContext(t, tc)
+
+	resp, err := handler.GetPage()(c, cnf)
+	require.NoError(t, err)
+
+	out, err := json.MarshalIndent(resp, "", "  ")
+	require.NoError(t, err)
+
+	return out
+}
+
+func readPageInfo(t *testing.T, path string) *pbTypes.PageInfo {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	pInfo := &pbTypes.PageInfo{}
+	require.NoError(t, protojson.Unmarshal(b, pInfo))
+
+	return pInfo
+}
+
+func newContext(t *testing.T, tc Case) echo.Context {
+	t.Helper()
+
+	body, err := json.Marshal(page.GetPageRequest{PageURL: tc.PageURL, UserContext: tc.UserContext})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	if tc.Claims != nil {
+		intrnl.PopulateClaims(&c, tc.Claims)
+	}
+
+	return c
+}
+
+// fakePageService serves the PageInfo fixture for GetPageFromCache, every other downstream call is unimplemented
+type fakePageService struct {
+	pageInfo *pbTypes.PageInfo
+}
+
+func (f *fakePageService) GetConn(_ echo.Context, _ logger.Logger, _ string, _ *config.Config) (googleGRPC.ClientConnInterface, error) {
+	return f, nil
+}
+
+func (f *fakePageService) Invoke(_ context.Context, method string, _, reply any, _ ...googleGRPC.CallOption) error {
+	if r, ok := reply.(*response.GetPageReply); ok {
+		r.PageInfo = proto.Clone(f.pageInfo).(*pbTypes.PageInfo)
+		return nil
+	}
+
+	return status.Errorf(codes.Unimplemented, "pagetest: %s is not faked", method)
+}
+
+func (*fakePageService) NewStream(_ context.Context, _ *googleGRPC.StreamDesc, method string, _ ...googleGRPC.CallOption) (googleGRPC.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "pagetest: %s is not faked", method)
+}
@@ -0,0 +1,41 @@
+package pagetest
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	commonModels "github.com/Allen-Career-Institute/go-bff-commons/v1/framework/models/commons"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl"
+)
+
+func TestRunListPage(t *testing.T) {
+	Run(t, Case{
+		PageInfo: filepath.Join("testdata", "list_page.json"),
+		Golden:   filepath.Join("testdata", "list_page.golden.json"),
+		PageURL:  "/home",
+		DataSources: map[string]DataSource{
+			"GreetingDS": StaticDataSource(map[string]any{"title": "Hello"}),
+			// the widget of a failing datasource is left out of the page
+			"BrokenDS": {Handler: func(_ echo.Context, _ *config.Config) (commonModels.DSResponse, error) {
+				return intrnl.PopulateResponse(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil), nil
+			}},
+		},
+	})
+}
+
+func TestRunNestedTabPage(t *testing.T) {
+	// the selected tab is a tab page itself, the page of its selected tab is resolved as well and unselected tabs
+	// are sent without their page
+	Run(t, Case{
+		PageInfo: filepath.Join("testdata", "tab_page.json"),
+		Golden:   filepath.Join("testdata", "tab_page.golden.json"),
+		PageURL:  "/learn",
+		DataSources: map[string]DataSource{
+			"CourseDS": StaticDataSource(map[string]any{"title": "Physics"}),
+		},
+	})
+}
@@ -2,6 +2,9 @@ package dynamicconfig
 
 import (
 	"context"
+	"fmt"
+	"os"
+
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
 	"github.com/Allen-Career-Institute/go-kratos-commons/dynamicconfig/v1/factory"
@@ -31,5 +34,17 @@ func NewDynamicConfig(cfg *config.Config, l logger.Logger) (dc.DynamicConfig, er
 		return nil, err
 	}
 
+	// the client profiles read from App Config are validated once it is initialised, see config.Config.Validate
+	withDynamicConfig := *cfg
+	withDynamicConfig.DynamicConfig = dynamicConfig
+
+	if err = withDynamicConfig.ValidateClients(); err != nil {
+		if os.Getenv("ENV") != "" {
+			return nil, fmt.Errorf("invalid client config: %w", err)
+		}
+
+		l.Warnf("invalid client config, using defaults for the invalid values: %v", err)
+	}
+
 	return dynamicConfig, nil
 }
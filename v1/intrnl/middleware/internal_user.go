@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	m "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+// InternalUserMiddleware allows only the logged-in internal users, teachers are rejected unlike IsInternalUser of the intrnl package.
+// It is registered after AuthNMiddleware, which sets the claims of the user.
+func (m *Manager) InternalUserMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !isInternalUser(c) {
+			m.logger.WithContext(c).Warnf("InternalUserMiddleware: user-%v is not an internal user", c.Get(utils.UserID))
+			return &echo.HTTPError{Message: AccessFailedMessage, Code: http.StatusForbidden}
+		}
+
+		return next(c)
+	}
+}
+
+func isInternalUser(c echo.Context) bool {
+	persona, _ := c.Get(utils.PersonaType).(string)
+	return m.IsUserLoggedIn(c) && persona == utils.PersonaTypeInternalUser
+}
@@ -7,6 +7,7 @@ import (
 	internal "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/clients"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
@@ -22,8 +23,13 @@ const (
 	TooManyRequests       = 429
 )
 
+// AuthNMiddleware validates the token of the user and sets its claims on the echo context, requests without a token
+// are served as guests. The metadata forwarded to the downstream services is captured once the claims are set, so that
+// every outbound grpc and http call of the route forwards it.
 func (m *Manager) AuthNMiddleware(conf *config.Config) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		next = propagation.Middleware(conf)(next)
+
 		return func(c echo.Context) error {
 			m.logger.WithContext(c).Debug("AuthN Middleware - Execution ")
 			authHeader := c.Request().Header.Get("Authorization")
@@ -0,0 +1,132 @@
+// Package faults injects latency and errors into downstream calls and datasources for chaos testing, driven by rules in
+// dynamic config. It is always disabled when ENV is prod.
+package faults
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+// RulesKey is the dynamic config key of the fault injection rules, a JSON array of Rule, e.g.
+//
+//	[{"client": "user-service", "method": "/user.User/GetUser", "percentage": 20, "delay_ms": 500},
+//	 {"datasource": "home_page", "percentage": 5, "http_status": 503}]
+const RulesKey = "fault_injection.rules"
+
+// Rule injects a fault into a percentage of the matching calls. A rule with a client or a method matches the grpc calls
+// of its client and method, made from its datasource when one is set. A rule with only a datasource matches the
+// executions of the datasource.
+type Rule struct {
+	Client     string     `json:"client,omitempty"`      // grpc client config name
+	Method     string     `json:"method,omitempty"`      // fully-qualified grpc method, e.g. /package.Service/Method
+	DataSource string     `json:"datasource,omitempty"`  // datasource name
+	Percentage float64    `json:"percentage"`            // percentage of the matching calls the fault is injected into
+	DelayMs    int64      `json:"delay_ms,omitempty"`    // latency added before the call
+	Code       codes.Code `json:"code,omitempty"`        // grpc code the grpc calls are aborted with, e.g. "UNAVAILABLE"
+	HTTPStatus int        `json:"http_status,omitempty"` // http status the datasource executions are aborted with
+}
+
+// Delay returns the latency injected by the rule
+func (r Rule) Delay() time.Duration {
+	return time.Duration(r.DelayMs) * time.Millisecond
+}
+
+// Injector finds the rule of a call, a disabled injector never matches
+type Injector struct {
+	cnf     *config.Config
+	logger  logger.Logger
+	enabled bool
+	roll    func() float64
+}
+
+// NewInjector returns the injector of the rules in dynamic config, it is disabled when ENV is prod or there is no
+// dynamic config
+func NewInjector(cnf *config.Config, log logger.Logger) *Injector {
+	return &Injector{
+		cnf:     cnf,
+		logger:  log,
+		enabled: os.Getenv(utils.Env) != utils.EnvProd && cnf.DynamicConfig != nil,
+		roll:    rand.Float64,
+	}
+}
+
+// Enabled reports whether faults may be injected
+func (i *Injector) Enabled() bool {
+	return i != nil && i.enabled
+}
+
+// MatchCall returns the rule of the grpc call of the client, made from the datasource, and whether a fault is injected
+// into the call
+func (i *Injector) MatchCall(client, method, dataSource string) (Rule, bool) {
+	return i.match(func(r Rule) bool {
+		return (r.Client != "" || r.Method != "") &&
+			matches(r.Client, client) && matches(r.Method, method) && matches(r.DataSource, dataSource)
+	})
+}
+
+// MatchDataSource returns the rule of the execution of the datasource, and whether a fault is injected into it
+func (i *Injector) MatchDataSource(dataSource string) (Rule, bool) {
+	return i.match(func(r Rule) bool {
+		return r.Client == "" && r.Method == "" && r.DataSource == dataSource
+	})
+}
+
+func (i *Injector) match(selects func(Rule) bool) (Rule, bool) {
+	if !i.Enabled() {
+		return Rule{}, false
+	}
+
+	for _, rule := range i.rules() {
+		if selects(rule) {
+			return rule, i.roll()*100 < rule.Percentage
+		}
+	}
+
+	return Rule{}, false
+}
+
+// rules reads the rules from dynamic config, invalid rules are logged and ignored
+func (i *Injector) rules() []Rule {
+	raw, err := i.cnf.DynamicConfig.Get(RulesKey)
+	if err != nil || raw == "" {
+		return nil
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		i.logger.Errorf("error parsing fault injection rules, err: %v", err)
+		return nil
+	}
+
+	return rules
+}
+
+// Sleep waits for the delay of the rule, it returns early with the error of the ctx when the ctx is done first
+func Sleep(ctx context.Context, rule Rule) error {
+	if rule.DelayMs <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(rule.Delay())
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func matches(selector, value string) bool {
+	return selector == "" || selector == value
+}
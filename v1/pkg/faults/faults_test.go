@@ -0,0 +1,136 @@
+package faults
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+const testRules = `[
+	{"client": "user-service", "method": "/user.User/GetUser", "percentage": 50, "delay_ms": 200},
+	{"client": "page-service", "datasource": "home_page", "percentage": 100, "code": "UNAVAILABLE"},
+	{"datasource": "home_page", "percentage": 100, "http_status": 503}
+]`
+
+func newTestInjector(t *testing.T, rules string, roll float64) *Injector {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	dc := config.NewMockDynamicConfig(ctrl)
+	dc.EXPECT().Get(RulesKey).Return(rules, nil).AnyTimes()
+
+	var log logger.Logger = logger.NewAPILogger(&config.Config{Logger: config.Logger{Level: "error"}})
+	log.InitLogger()
+
+	injector := NewInjector(&config.Config{DynamicConfig: dc}, log)
+	injector.roll = func() float64 { return roll }
+
+	return injector
+}
+
+func TestInjector_MatchCall(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      string
+		roll       float64
+		client     string
+		method     string
+		dataSource string
+		wantRule   Rule
+		wantInject bool
+	}{
+		{
+			name:       "call within the percentage",
+			rules:      testRules,
+			roll:       0.4,
+			client:     "user-service",
+			method:     "/user.User/GetUser",
+			wantRule:   Rule{Client: "user-service", Method: "/user.User/GetUser", Percentage: 50, DelayMs: 200},
+			wantInject: true,
+		},
+		{
+			name:     "call outside the percentage",
+			rules:    testRules,
+			roll:     0.6,
+			client:   "user-service",
+			method:   "/user.User/GetUser",
+			wantRule: Rule{Client: "user-service", Method: "/user.User/GetUser", Percentage: 50, DelayMs: 200},
+		},
+		{
+			name:       "call from the datasource of the rule",
+			rules:      testRules,
+			client:     "page-service",
+			method:     "/page.Page/GetPage",
+			dataSource: "home_page",
+			wantRule:   Rule{Client: "page-service", DataSource: "home_page", Percentage: 100, Code: codes.Unavailable},
+			wantInject: true,
+		},
+		{
+			name:       "call from another datasource",
+			rules:      testRules,
+			client:     "page-service",
+			method:     "/page.Page/GetPage",
+			dataSource: "profile_page",
+		},
+		{
+			name:   "invalid rules",
+			rules:  "invalid",
+			client: "user-service",
+			method: "/user.User/GetUser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, inject := newTestInjector(t, tt.rules, tt.roll).MatchCall(tt.client, tt.method, tt.dataSource)
+
+			assert.Equal(t, tt.wantRule, rule)
+			assert.Equal(t, tt.wantInject, inject)
+		})
+	}
+}
+
+func TestInjector_MatchDataSource(t *testing.T) {
+	injector := newTestInjector(t, testRules, 0)
+
+	rule, inject := injector.MatchDataSource("home_page")
+	assert.True(t, inject)
+	assert.Equal(t, Rule{DataSource: "home_page", Percentage: 100, HTTPStatus: 503}, rule)
+
+	_, inject = injector.MatchDataSource("profile_page")
+	assert.False(t, inject)
+}
+
+func TestNewInjectorEnabled(t *testing.T) {
+	dc := config.NewMockDynamicConfig(gomock.NewController(t))
+
+	t.Setenv(utils.Env, utils.EnvStage)
+	assert.True(t, NewInjector(&config.Config{DynamicConfig: dc}, nil).Enabled())
+	assert.False(t, NewInjector(&config.Config{}, nil).Enabled(), "disabled without dynamic config")
+
+	t.Setenv(utils.Env, utils.EnvProd)
+	injector := NewInjector(&config.Config{DynamicConfig: dc}, nil)
+	assert.False(t, injector.Enabled(), "disabled in prod")
+
+	_, inject := injector.MatchDataSource("home_page")
+	assert.False(t, inject)
+}
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), Rule{DelayMs: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	assert.ErrorIs(t, Sleep(ctx, Rule{DelayMs: 1000}), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
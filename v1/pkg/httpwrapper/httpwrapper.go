@@ -2,9 +2,12 @@ package httpwrapper
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
 )
 
 type httpWrapper struct{}
@@ -15,12 +18,18 @@ func NewHTTPWrapper() IHTTPClient {
 
 // MakeHttpCall TODO: @KodeGeass to add support for exponential retry & gitter
 func (h *httpWrapper) MakeHttpCall(url, method string, payload map[string]interface{}, headers map[string]string, requestCookies []*http.Cookie) ([]byte, []*http.Cookie, error) {
+	return h.MakeHttpCallWithContext(context.Background(), url, method, payload, headers, requestCookies)
+}
+
+// MakeHttpCallWithContext makes the call with the ctx of the request, the metadata captured by the propagation policy
+// of the request is forwarded as headers
+func (h *httpWrapper) MakeHttpCallWithContext(ctx context.Context, url, method string, payload map[string]interface{}, headers map[string]string, requestCookies []*http.Cookie) ([]byte, []*http.Cookie, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -33,7 +42,7 @@ func (h *httpWrapper) MakeHttpCall(url, method string, payload map[string]interf
 		req.AddCookie(cookie)
 	}
 
-	client := &http.Client{}
+	client := &http.Client{Transport: propagation.Transport(nil)}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, err
@@ -2,11 +2,16 @@ package httpwrapper
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
 )
 
 // Helper method to create a test server
@@ -98,3 +103,19 @@ func TestMakeHttpCall(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeHttpCallWithContext(t *testing.T) {
+	ts := createTestServer(t, "GET", `{"message": "success"}`, nil, map[string]string{
+		"Authorization": "Bearer token",
+		"Custom-Header": "HeaderValue",
+	})
+	defer ts.Close()
+
+	ctx := propagation.NewContext(context.Background(), metadata.Pairs("Authorization", "Bearer token"))
+
+	httpwrapper := NewHTTPWrapper()
+	_, _, err := httpwrapper.MakeHttpCallWithContext(ctx, ts.URL, "GET", nil, map[string]string{"Custom-Header": "HeaderValue"}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got '%v'", err)
+	}
+}
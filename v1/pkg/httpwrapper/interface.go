@@ -1,9 +1,11 @@
 package httpwrapper
 
 import (
+	"context"
 	"net/http"
 )
 
 type IHTTPClient interface {
 	MakeHttpCall(url, method string, payload map[string]interface{}, headers map[string]string, requestCookies []*http.Cookie) ([]byte, []*http.Cookie, error)
+	MakeHttpCallWithContext(ctx context.Context, url, method string, payload map[string]interface{}, headers map[string]string, requestCookies []*http.Cookie) ([]byte, []*http.Cookie, error)
 }
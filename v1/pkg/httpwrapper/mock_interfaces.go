@@ -5,6 +5,7 @@
 package httpwrapper
 
 import (
+	context "context"
 	http "net/http"
 	reflect "reflect"
 
@@ -49,3 +50,19 @@ func (mr *MockIHTTPClientMockRecorder) MakeHttpCall(url, method, payload, header
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeHttpCall", reflect.TypeOf((*MockIHTTPClient)(nil).MakeHttpCall), url, method, payload, headers, requestCookies)
 }
+
+// MakeHttpCallWithContext mocks base method.
+func (m *MockIHTTPClient) MakeHttpCallWithContext(ctx context.Context, url, method string, payload map[string]interface{}, headers map[string]string, requestCookies []*http.Cookie) ([]byte, []*http.Cookie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeHttpCallWithContext", ctx, url, method, payload, headers, requestCookies)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]*http.Cookie)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MakeHttpCallWithContext indicates an expected call of MakeHttpCallWithContext.
+func (mr *MockIHTTPClientMockRecorder) MakeHttpCallWithContext(ctx, url, method, payload, headers, requestCookies interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeHttpCallWithContext", reflect.TypeOf((*MockIHTTPClient)(nil).MakeHttpCallWithContext), ctx, url, method, payload, headers, requestCookies)
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	bulkheadRejectedCount     = "bulkhead_rejected_count"
+	bulkheadRejectedCountDesc = "Downstream calls rejected because the bulkhead of the client was full"
+)
+
+type BulkheadMetrics struct {
+	RejectedCount metric.Int64Counter
+}
+
+func NewBulkheadMetrics(meter metric.Meter) (*BulkheadMetrics, error) {
+	counters := []MetricParams{
+		{Name: bulkheadRejectedCount, Desc: bulkheadRejectedCountDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkheadMetrics{
+		RejectedCount: metrics[bulkheadRejectedCount].(metric.Int64Counter),
+	}, nil
+}
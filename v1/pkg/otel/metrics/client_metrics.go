@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	clientRequestCount     = "grpc_client_request_count"
+	clientRequestCountDesc = "Calls to the downstream of a grpc client"
+	clientErrorCount       = "grpc_client_error_count"
+	clientErrorCountDesc   = "Failed calls to the downstream of a grpc client by grpc code"
+	clientLatency          = "grpc_client_latency"
+	clientLatencyDesc      = "Latency in seconds of the calls to the downstream of a grpc client"
+)
+
+type ClientMetrics struct {
+	RequestCount metric.Int64Counter
+	ErrorCount   metric.Int64Counter
+	Latency      metric.Float64Histogram
+}
+
+func NewClientMetrics(meter metric.Meter) (*ClientMetrics, error) {
+	counters := []MetricParams{
+		{Name: clientRequestCount, Desc: clientRequestCountDesc},
+		{Name: clientErrorCount, Desc: clientErrorCountDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	latency, err := createHistogram(meter, MetricParams{Name: clientLatency, Desc: clientLatencyDesc})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientMetrics{
+		RequestCount: metrics[clientRequestCount].(metric.Int64Counter),
+		ErrorCount:   metrics[clientErrorCount].(metric.Int64Counter),
+		Latency:      latency,
+	}, nil
+}
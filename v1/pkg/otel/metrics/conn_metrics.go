@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	grpcConnSwapCount     = "grpc_conn_swap_count"
+	grpcConnSwapCountDesc = "gRPC client connections rebuilt after a change of their dynamic config"
+)
+
+type ConnMetrics struct {
+	SwapCount metric.Int64Counter
+}
+
+func NewConnMetrics(meter metric.Meter) (*ConnMetrics, error) {
+	counters := []MetricParams{
+		{Name: grpcConnSwapCount, Desc: grpcConnSwapCountDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnMetrics{
+		SwapCount: metrics[grpcConnSwapCount].(metric.Int64Counter),
+	}, nil
+}
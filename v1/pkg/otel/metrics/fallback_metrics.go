@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	fallbackServedCount     = "fallback_served_count"
+	fallbackServedCountDesc = "Failed downstream calls served with the last good response of the method"
+	fallbackMissCount       = "fallback_miss_count"
+	fallbackMissCountDesc   = "Failed downstream calls without a last good response to serve"
+)
+
+type FallbackMetrics struct {
+	ServedCount metric.Int64Counter
+	MissCount   metric.Int64Counter
+}
+
+func NewFallbackMetrics(meter metric.Meter) (*FallbackMetrics, error) {
+	counters := []MetricParams{
+		{Name: fallbackServedCount, Desc: fallbackServedCountDesc},
+		{Name: fallbackMissCount, Desc: fallbackMissCountDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FallbackMetrics{
+		ServedCount: metrics[fallbackServedCount].(metric.Int64Counter),
+		MissCount:   metrics[fallbackMissCount].(metric.Int64Counter),
+	}, nil
+}
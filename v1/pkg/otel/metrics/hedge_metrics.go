@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	hedgeCount                = "hedge_count"
+	hedgeCountDesc            = "Hedged calls sent for slow downstream calls"
+	hedgeWinCount             = "hedge_win_count"
+	hedgeWinCountDesc         = "Hedged calls which answered before the original call"
+	hedgeBudgetExhaustedCount = "hedge_budget_exhausted_count"
+	hedgeBudgetExhaustedDesc  = "Hedged calls skipped because the hedge budget of the client was exhausted"
+)
+
+type HedgeMetrics struct {
+	HedgeCount           metric.Int64Counter
+	WinCount             metric.Int64Counter
+	BudgetExhaustedCount metric.Int64Counter
+}
+
+func NewHedgeMetrics(meter metric.Meter) (*HedgeMetrics, error) {
+	counters := []MetricParams{
+		{Name: hedgeCount, Desc: hedgeCountDesc},
+		{Name: hedgeWinCount, Desc: hedgeWinCountDesc},
+		{Name: hedgeBudgetExhaustedCount, Desc: hedgeBudgetExhaustedDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HedgeMetrics{
+		HedgeCount:           metrics[hedgeCount].(metric.Int64Counter),
+		WinCount:             metrics[hedgeWinCount].(metric.Int64Counter),
+		BudgetExhaustedCount: metrics[hedgeBudgetExhaustedCount].(metric.Int64Counter),
+	}, nil
+}
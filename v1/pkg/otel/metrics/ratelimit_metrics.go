@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	rateLimitThrottledCount     = "ratelimit_throttled_count"
+	rateLimitThrottledCountDesc = "Downstream calls delayed by the rate limit of the client"
+	rateLimitRejectedCount      = "ratelimit_rejected_count"
+	rateLimitRejectedCountDesc  = "Downstream calls rejected by the rate limit of the client"
+)
+
+type RateLimitMetrics struct {
+	ThrottledCount metric.Int64Counter
+	RejectedCount  metric.Int64Counter
+}
+
+func NewRateLimitMetrics(meter metric.Meter) (*RateLimitMetrics, error) {
+	counters := []MetricParams{
+		{Name: rateLimitThrottledCount, Desc: rateLimitThrottledCountDesc},
+		{Name: rateLimitRejectedCount, Desc: rateLimitRejectedCountDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateLimitMetrics{
+		ThrottledCount: metrics[rateLimitThrottledCount].(metric.Int64Counter),
+		RejectedCount:  metrics[rateLimitRejectedCount].(metric.Int64Counter),
+	}, nil
+}
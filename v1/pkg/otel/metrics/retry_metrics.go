@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	retryAttemptCount             = "retry_attempt_count"
+	retryAttemptCountDesc         = "Retry attempts made for downstream calls"
+	retryExceededCount            = "retry_exceeded_count"
+	retryExceededCountDesc        = "Downstream calls which failed after exhausting all retries"
+	retryBudgetExhaustedCount     = "retry_budget_exhausted_count"
+	retryBudgetExhaustedCountDesc = "Retries skipped because the retry budget of the client was exhausted"
+)
+
+type RetryMetrics struct {
+	AttemptCount         metric.Int64Counter
+	ExceededCount        metric.Int64Counter
+	BudgetExhaustedCount metric.Int64Counter
+}
+
+func NewRetryMetrics(meter metric.Meter) (*RetryMetrics, error) {
+	counters := []MetricParams{
+		{Name: retryAttemptCount, Desc: retryAttemptCountDesc},
+		{Name: retryExceededCount, Desc: retryExceededCountDesc},
+		{Name: retryBudgetExhaustedCount, Desc: retryBudgetExhaustedCountDesc},
+	}
+
+	metrics, err := createMetrics(meter, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RetryMetrics{
+		AttemptCount:         metrics[retryAttemptCount].(metric.Int64Counter),
+		ExceededCount:        metrics[retryExceededCount].(metric.Int64Counter),
+		BudgetExhaustedCount: metrics[retryBudgetExhaustedCount].(metric.Int64Counter),
+	}, nil
+}
@@ -2,6 +2,8 @@ package otel
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"time"
 
@@ -27,6 +29,9 @@ import (
 type Handler struct {
 	Cnf config.Config
 	log logger.Logger
+
+	meterProvider  *metric.MeterProvider
+	tracerProvider *trace.TracerProvider
 }
 
 func NewHandler(cnf *config.Config, log logger.Logger) *Handler {
@@ -84,6 +89,7 @@ func (h *Handler) InitOtelProviders(endpoint1 string, endpoint2 string) {
 
 	meterProvider := metric.NewMeterProvider(meterProviderOptions...)
 	otel.SetMeterProvider(meterProvider)
+	h.meterProvider = meterProvider
 	// The MeterProvider is configured and registered globally. You can now run
 	// your code instrumented with the OpenTelemetry API that uses the global
 	// MeterProvider without having to pass this MeterProvider instance. Or,
@@ -114,9 +120,37 @@ func (h *Handler) InitOtelProviders(endpoint1 string, endpoint2 string) {
 
 	tracerProvider := trace.NewTracerProvider(traceProviderOptions...)
 	otel.SetTracerProvider(tracerProvider)
+	h.tracerProvider = tracerProvider
 	otel.SetTextMapPropagator(propagation.TraceContext{})
 }
 
+// Shutdown flushes the metrics and spans which are not exported yet and stops the providers, so that the telemetry of
+// the last seconds before a deploy is not lost. It is a no-op when the providers are not initialised.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if h.tracerProvider != nil {
+		if err := h.tracerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error shutting down tracer provider: %w", err))
+		}
+	}
+
+	if h.meterProvider != nil {
+		if err := h.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error shutting down meter provider: %w", err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		h.log.Errorf("error flushing telemetry, err: %v", err)
+		return err
+	}
+
+	h.log.Infof("flushed telemetry")
+
+	return nil
+}
+
 func Trace(c echo.Context, name string) (echo.Context, spanTrace.Span) {
 	parentCtx := utils.GetRequestCtx(c)
 	tracer := spanTrace.SpanFromContext(parentCtx).TracerProvider().Tracer(utils.SpanTracer)
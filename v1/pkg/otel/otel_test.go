@@ -2,6 +2,7 @@
 package otel
 
 import (
+	"context"
 	"errors"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
 )
 
 func TestDeltaSelector(t *testing.T) {
@@ -330,3 +332,20 @@ func (m *mockLogger) Fatal(args ...interface{}) {
 func (m *mockLogger) Fatalf(template string, args ...interface{}) {
 	m.t.Logf(template, args...)
 }
+
+func TestHandler_Shutdown(t *testing.T) {
+	log := logger.NewAPILogger(&config.Config{Logger: config.Logger{Level: "error"}})
+	log.InitLogger()
+
+	h := NewHandler(&config.Config{}, log)
+	assert.NoError(t, h.Shutdown(context.Background()), "shutdown is a no-op without providers")
+
+	reader := metric.NewManualReader()
+	h.meterProvider = metric.NewMeterProvider(metric.WithReader(reader))
+	h.tracerProvider = trace.NewTracerProvider()
+
+	assert.NoError(t, h.Shutdown(context.Background()))
+
+	// the providers are stopped, so a second shutdown fails
+	assert.Error(t, h.Shutdown(context.Background()))
+}
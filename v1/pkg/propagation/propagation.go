@@ -0,0 +1,289 @@
+// Package propagation forwards request metadata to the downstream services by a declarative policy: an allowlist of
+// inbound headers, values derived from the claims of the user, and the W3C baggage of the request. The values are
+// captured once per request and applied to every outbound grpc and http call by an interceptor, so that the calls made
+// by page widget workers from a cloned request forward the same metadata as the calls made by the route.
+package propagation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel/baggage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+// Dynamic config keys of the policy, when a key is not set its default is kept
+const (
+	HeadersKey = "propagation.headers" // comma separated inbound headers, e.g. "Authorization,X-Device-ID"
+	ClaimsKey  = "propagation.claims"  // comma separated claims, e.g. "user_id,persona,session"
+	BaggageKey = "propagation.baggage" // "true" forwards the W3C baggage of the request
+)
+
+// Claims which can be forwarded, they are set on the echo context when the token of the user is validated
+const (
+	ClaimUserID   = "user_id"
+	ClaimPersona  = "persona"
+	ClaimSession  = "session"
+	ClaimTenantID = "tenant_id"
+)
+
+// Outbound keys of the forwarded claims and baggage
+const (
+	UserIDHeader   = "x-user-id"
+	PersonaHeader  = "x-persona-type"
+	SessionHeader  = "x-session-id"
+	TenantIDHeader = "x-tenant-id"
+	BaggageHeader  = "baggage"
+)
+
+type claim struct {
+	ctxKey string
+	header string
+}
+
+//nolint:gochecknoglobals // echo context keys and outbound keys of the claims which can be forwarded
+var claims = map[string]claim{
+	ClaimUserID:   {ctxKey: utils.UserID, header: UserIDHeader},
+	ClaimPersona:  {ctxKey: utils.PersonaType, header: PersonaHeader},
+	ClaimSession:  {ctxKey: utils.SessionID, header: SessionHeader},
+	ClaimTenantID: {ctxKey: utils.TenantID, header: TenantIDHeader},
+}
+
+// Policy selects the metadata forwarded to the downstream services. The request id and the service name are always
+// forwarded.
+type Policy struct {
+	Headers []string // inbound headers forwarded as they are
+	Claims  []string // claims forwarded, see ClaimUserID
+	Baggage bool     // whether the W3C baggage of the request is forwarded
+}
+
+// DefaultPolicy forwards the headers which have always been forwarded to the downstream services
+func DefaultPolicy() Policy {
+	return Policy{Headers: []string{echo.HeaderAuthorization, utils.DeviceID, utils.DeviceType, utils.VisitorID}}
+}
+
+// PolicyFromConfig reads the policy from dynamic config, keys which are not set keep the default policy. Claims which
+// cannot be forwarded are logged and skipped.
+func PolicyFromConfig(cnf *config.Config) Policy {
+	policy := DefaultPolicy()
+	if cnf == nil || cnf.DynamicConfig == nil {
+		return policy
+	}
+
+	if headers := getOrEmpty(cnf, HeadersKey); headers != "" {
+		policy.Headers = splitList(headers)
+	}
+
+	if names := getOrEmpty(cnf, ClaimsKey); names != "" {
+		policy.Claims = splitList(names)
+
+		if err := policy.Validate(); err != nil {
+			log.Errorf("invalid %s in dynamic config, err: %v", ClaimsKey, err)
+		}
+	}
+
+	if forward, err := strconv.ParseBool(getOrEmpty(cnf, BaggageKey)); err == nil {
+		policy.Baggage = forward
+	}
+
+	return policy
+}
+
+// Validate reports the claims of the policy which cannot be forwarded
+func (p Policy) Validate() error {
+	for _, name := range p.Claims {
+		if _, ok := claims[name]; !ok {
+			return fmt.Errorf("unsupported propagation claim: %s", name)
+		}
+	}
+
+	return nil
+}
+
+// Capture returns the metadata of the request forwarded by the policy, unsupported claims and empty values are skipped
+func Capture(c echo.Context, p Policy) metadata.MD {
+	md := metadata.MD{}
+	req := c.Request()
+
+	for _, header := range p.Headers {
+		if value := req.Header.Get(header); value != "" {
+			md.Set(header, value)
+		}
+	}
+
+	for _, name := range p.Claims {
+		cl, ok := claims[name]
+		if !ok {
+			continue
+		}
+
+		if value, ok := c.Get(cl.ctxKey).(string); ok && value != "" {
+			md.Set(cl.header, value)
+		}
+	}
+
+	if p.Baggage {
+		if value := captureBaggage(req); value != "" {
+			md.Set(BaggageHeader, value)
+		}
+	}
+
+	if requestID := utils.GetRequestID(c); requestID != "" {
+		md.Set(echo.HeaderXRequestID, requestID)
+	}
+
+	md.Set(utils.ServiceHeader, utils.TracerServiceName)
+
+	return md
+}
+
+// captureBaggage merges the baggage header of the request with the baggage of its context, invalid baggage is dropped
+func captureBaggage(req *http.Request) string {
+	inbound, err := baggage.Parse(req.Header.Get(BaggageHeader))
+	if err != nil {
+		inbound = baggage.Baggage{}
+	}
+
+	for _, member := range baggage.FromContext(req.Context()).Members() {
+		if merged, err := inbound.SetMember(member); err == nil {
+			inbound = merged
+		}
+	}
+
+	return inbound.String()
+}
+
+type mdCtxKey struct{}
+
+// NewContext returns the ctx carrying the metadata forwarded to the downstream services
+func NewContext(ctx context.Context, md metadata.MD) context.Context {
+	return context.WithValue(ctx, mdCtxKey{}, md)
+}
+
+// FromContext returns the metadata forwarded to the downstream services, set by NewContext
+func FromContext(ctx context.Context) metadata.MD {
+	md, _ := ctx.Value(mdCtxKey{}).(metadata.MD)
+	return md
+}
+
+// Middleware captures the metadata of every request by the policy in dynamic config. It runs once the claims of the
+// user are set on the echo context, the AuthN middleware of the service runs it for every request.
+func Middleware(cnf *config.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := NewContext(c.Request().Context(), Capture(c, PolicyFromConfig(cnf)))
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// UnaryClientInterceptor adds the forwarded metadata of the ctx to the outgoing metadata of every call, keys which are
+// already set on the call are kept
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor adds the forwarded metadata of the ctx to the outgoing metadata of every stream, keys which
+// are already set on the stream are kept
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	forwarded := FromContext(ctx)
+	if len(forwarded) == 0 {
+		return ctx
+	}
+
+	outgoing, _ := metadata.FromOutgoingContext(ctx)
+
+	var kv []string
+
+	for key, values := range forwarded {
+		if len(outgoing.Get(key)) > 0 {
+			continue
+		}
+
+		for _, value := range values {
+			kv = append(kv, key, value)
+		}
+	}
+
+	if len(kv) == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// Transport adds the forwarded metadata of the request context to the headers of every outbound http request, headers
+// which are already set on the request are kept. A nil base uses http.DefaultTransport.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return roundTripper{base: base}
+}
+
+type roundTripper struct {
+	base http.RoundTripper
+}
+
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	forwarded := FromContext(req.Context())
+	if len(forwarded) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+
+	for key, values := range forwarded {
+		if req.Header.Get(key) != "" {
+			continue
+		}
+
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+func splitList(list string) []string {
+	var items []string
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func getOrEmpty(cnf *config.Config, key string) string {
+	value, err := cnf.DynamicConfig.Get(key)
+	if err != nil {
+		return ""
+	}
+
+	return value
+}
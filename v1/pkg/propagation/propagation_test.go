@@ -0,0 +1,201 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
+)
+
+func newTestContext() echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+	req.Header.Set(utils.DeviceID, "device-1")
+	req.Header.Set(utils.DeviceType, "web")
+	req.Header.Set("X-Experiment", "exp-1")
+	req.Header.Set(BaggageHeader, "tenant=allen")
+
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+	c.Set(utils.UserID, "user-1")
+	c.Set(utils.PersonaType, utils.PersonaTypeStudent)
+
+	return c
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   Policy
+	}{
+		{
+			name: "Dynamic config read from AWS App Config",
+			values: map[string]string{
+				HeadersKey: "Authorization, X-Experiment",
+				ClaimsKey:  "user_id,persona",
+				BaggageKey: "true",
+			},
+			want: Policy{
+				Headers: []string{echo.HeaderAuthorization, "X-Experiment"},
+				Claims:  []string{ClaimUserID, ClaimPersona},
+				Baggage: true,
+			},
+		},
+		{
+			name: "Dynamic config with an unsupported claim",
+			values: map[string]string{
+				ClaimsKey: "user_id,usr_id",
+			},
+			want: Policy{
+				Headers: DefaultPolicy().Headers,
+				Claims:  []string{ClaimUserID, "usr_id"},
+			},
+		},
+		{
+			name:   "Dynamic config without policy",
+			values: map[string]string{},
+			want:   DefaultPolicy(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dc := config.NewMockDynamicConfig(ctrl)
+
+			for _, key := range []string{HeadersKey, ClaimsKey, BaggageKey} {
+				dc.EXPECT().Get(key).Return(tt.values[key], nil)
+			}
+
+			assert.Equal(t, tt.want, PolicyFromConfig(&config.Config{DynamicConfig: dc}))
+		})
+	}
+
+	assert.Equal(t, DefaultPolicy(), PolicyFromConfig(nil))
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	assert.NoError(t, Policy{Claims: []string{ClaimUserID, ClaimPersona, ClaimSession, ClaimTenantID}}.Validate())
+	assert.ErrorContains(t, Policy{Claims: []string{"email"}}.Validate(), "email")
+}
+
+func TestCapture(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		want   metadata.MD
+	}{
+		{
+			name:   "default policy",
+			policy: DefaultPolicy(),
+			want: metadata.Pairs(
+				echo.HeaderAuthorization, "Bearer token",
+				utils.DeviceID, "device-1",
+				utils.DeviceType, "web",
+				echo.HeaderXRequestID, "req-1",
+				utils.ServiceHeader, utils.TracerServiceName,
+			),
+		},
+		{
+			name: "headers, claims and baggage",
+			policy: Policy{
+				Headers: []string{"X-Experiment"},
+				Claims:  []string{ClaimUserID, ClaimPersona, ClaimSession, "email"},
+				Baggage: true,
+			},
+			want: metadata.Pairs(
+				"X-Experiment", "exp-1",
+				UserIDHeader, "user-1",
+				PersonaHeader, utils.PersonaTypeStudent,
+				BaggageHeader, "tenant=allen",
+				echo.HeaderXRequestID, "req-1",
+				utils.ServiceHeader, utils.TracerServiceName,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Capture(newTestContext(), tt.policy))
+		})
+	}
+}
+
+func TestCaptureMergesContextBaggage(t *testing.T) {
+	c := newTestContext()
+
+	member, err := baggage.NewMember("experiment", "exp-1")
+	require.NoError(t, err)
+	b, err := baggage.New(member)
+	require.NoError(t, err)
+
+	c.SetRequest(c.Request().WithContext(baggage.ContextWithBaggage(c.Request().Context(), b)))
+
+	got, err := baggage.Parse(Capture(c, Policy{Baggage: true}).Get(BaggageHeader)[0])
+	require.NoError(t, err)
+	assert.Equal(t, "allen", got.Member("tenant").Value())
+	assert.Equal(t, "exp-1", got.Member("experiment").Value())
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	ctx := NewContext(context.Background(), metadata.Pairs(echo.HeaderAuthorization, "Bearer token", UserIDHeader, "user-1"))
+	ctx = metadata.AppendToOutgoingContext(ctx, UserIDHeader, "user-2")
+
+	var got metadata.MD
+	err := UnaryClientInterceptor()(ctx, "/user.User/GetUser", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			got, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bearer token"}, got.Get(echo.HeaderAuthorization))
+	assert.Equal(t, []string{"user-2"}, got.Get(UserIDHeader), "metadata set on the call is kept")
+}
+
+func TestTransport(t *testing.T) {
+	var got http.Header
+
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	ctx := NewContext(context.Background(), metadata.Pairs(echo.HeaderAuthorization, "Bearer token", UserIDHeader, "user-1"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set(UserIDHeader, "user-2")
+
+	resp, err := (&http.Client{Transport: Transport(nil)}).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "Bearer token", got.Get(echo.HeaderAuthorization))
+	assert.Equal(t, "user-2", got.Get(UserIDHeader))
+	assert.Equal(t, "user-2", req.Header.Get(UserIDHeader))
+	assert.Empty(t, req.Header.Get(echo.HeaderAuthorization), "the request of the caller is not modified")
+}
+
+func TestMiddleware(t *testing.T) {
+	c := newTestContext()
+
+	err := Middleware(nil)(func(c echo.Context) error {
+		assert.Equal(t, []string{"Bearer token"}, FromContext(c.Request().Context()).Get(echo.HeaderAuthorization))
+		return nil
+	})(c)
+
+	require.NoError(t, err)
+}
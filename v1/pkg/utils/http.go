@@ -40,6 +40,21 @@ func HandleError(c echo.Context, err error, log logger.Logger) (code int, messag
 // ReqIDCtxKey is a key used for the Request ID in context
 type ReqIDCtxKey struct{}
 
+// DataSourceCtxKey is a key used for the name of the datasource serving the request in context
+type DataSourceCtxKey struct{}
+
+// WithDataSource returns the ctx with the name of the datasource serving the request, used to attribute the downstream
+// calls of the request to its datasource
+func WithDataSource(ctx context.Context, dsName string) context.Context {
+	return context.WithValue(ctx, DataSourceCtxKey{}, dsName)
+}
+
+// DataSourceFromContext returns the name of the datasource serving the request, empty when it is not served by one
+func DataSourceFromContext(ctx context.Context) string {
+	dsName, _ := ctx.Value(DataSourceCtxKey{}).(string)
+	return dsName
+}
+
 // GetCtxWithReqID Get ctx with timeout and request id from echo context
 func GetCtxWithReqID(c echo.Context) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second*15)
@@ -61,6 +76,49 @@ func GetRequestCtxWithTimeout(c echo.Context, timeout time.Duration) (context.Co
 	return ctx, connCancel
 }
 
+const (
+	// DeadlineSafetyMargin is kept out of the remaining budget of a request, so that a downstream call times out before
+	// the request does and the error can still be handled
+	DeadlineSafetyMargin = 10 * time.Millisecond
+	// MinCallBudget is the least time a downstream call needs, calls with less budget left fail without being sent
+	MinCallBudget = 20 * time.Millisecond
+)
+
+// ErrCallBudgetExhausted is returned when the remaining budget of the request is too low for a downstream call
+var ErrCallBudgetExhausted = status.Error(codes.DeadlineExceeded, "remaining request budget is too low for a downstream call")
+
+// RemainingBudget returns the time left before the deadline of the request, which is set by the request or the timeout
+// of its page or datasource, and false when the request has no deadline
+func RemainingBudget(c echo.Context) (time.Duration, bool) {
+	deadline, ok := c.Request().Context().Deadline()
+	if !ok {
+		return 0, false
+	}
+
+	return time.Until(deadline), true
+}
+
+// GetCallCtxWithBudget returns the context of a downstream call with request id, its timeout is the min of the client
+// timeout and the remaining budget of the request minus DeadlineSafetyMargin. It returns ErrCallBudgetExhausted when
+// less than MinCallBudget is left, so that the call fails fast instead of being sent.
+func GetCallCtxWithBudget(c echo.Context, timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	if remaining, ok := RemainingBudget(c); ok {
+		remaining -= DeadlineSafetyMargin
+		if remaining < MinCallBudget {
+			return nil, nil, ErrCallBudgetExhausted
+		}
+
+		timeout = min(timeout, remaining)
+	}
+
+	ctx, cancel := GetRequestCtxWithTimeout(c, timeout)
+
+	return ctx, cancel, nil
+}
+
+// AddAuthHeaderAsMetadata appends a fixed set of headers of the request to the outgoing metadata of the ctx.
+//
+// Deprecated: capture the metadata with propagation.Capture, the grpc clients forward it by the propagation policy.
 func AddAuthHeaderAsMetadata(ctx context.Context, c echo.Context) context.Context {
 	auth := c.Request().Header.Get(echo.HeaderAuthorization)
 	platform := c.Request().Header.Get(DeviceType)
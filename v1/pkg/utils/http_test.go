@@ -206,6 +206,75 @@ func TestGetRequestCtxWithTimeout(t *testing.T) {
 	}
 }
 
+func TestDataSourceFromContext(t *testing.T) {
+	assert.Equal(t, "", DataSourceFromContext(context.Background()))
+	assert.Equal(t, "page-ds", DataSourceFromContext(WithDataSource(context.Background(), "page-ds")))
+}
+
+func TestGetCallCtxWithBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		budget      time.Duration
+		timeout     time.Duration
+		wantTimeout time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "request without deadline uses the client timeout",
+			timeout:     time.Second,
+			wantTimeout: time.Second,
+		},
+		{
+			name:        "client timeout within the remaining budget",
+			budget:      5 * time.Second,
+			timeout:     time.Second,
+			wantTimeout: time.Second,
+		},
+		{
+			name:        "remaining budget below the client timeout",
+			budget:      500 * time.Millisecond,
+			timeout:     time.Second,
+			wantTimeout: 500*time.Millisecond - DeadlineSafetyMargin,
+		},
+		{
+			name:    "remaining budget below the floor",
+			budget:  MinCallBudget,
+			timeout: time.Second,
+			wantErr: ErrCallBudgetExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			if tt.budget > 0 {
+				reqCtx, reqCancel := context.WithTimeout(req.Context(), tt.budget)
+				defer reqCancel()
+
+				req = req.WithContext(reqCtx)
+			}
+
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			ctx, cancel, err := GetCallCtxWithBudget(c, tt.timeout)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Equal(t, http.StatusGatewayTimeout, GetHTTPStatusCode(status.Code(err)))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.InDelta(t, tt.wantTimeout, time.Until(deadline), float64(10*time.Millisecond))
+		})
+	}
+}
+
 func TestAddAuthHeaderAsMetadata(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/admin"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
+	apiMiddlewares "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/middleware"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+// MapAdminRoutes registers the endpoints used by on-call engineers to inspect and override the circuit breakers of the
+// grpc clients and to explain the resolved config, they are restricted to internal users
+func MapAdminRoutes(e *echo.Echo, cfg *config.Config, log logger.Logger, mw *apiMiddlewares.Manager) {
+	h := admin.NewHandler(grpc.DefaultBreakers(), config.DefaultSources(), log)
+	auth := []echo.MiddlewareFunc{mw.AuthNMiddleware(cfg), mw.InternalUserMiddleware}
+
+	e.GET(admin.BreakersPath, h.Breakers, auth...)
+	e.POST(admin.BreakerOverridePath, h.OverrideBreaker, auth...)
+	e.POST(admin.BreakerResetPath, h.ResetBreaker, auth...)
+	e.GET(admin.ConfigPath, h.Config, auth...)
+}
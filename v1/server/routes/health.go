@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/health"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+)
+
+// MapHealthRoutes registers the liveness and readiness endpoints, without any auth middleware so that kubernetes can
+// probe them. conns is usually the *grpc.Handler of the service.
+func MapHealthRoutes(e *echo.Echo, conns health.ConnHealthChecker, cfg *config.Config, log logger.Logger) {
+	h := health.NewHandler(conns, cfg, log)
+
+	e.GET(health.LivePath, h.Live)
+	e.GET(health.ReadyPath, h.Ready)
+}
@@ -5,11 +5,13 @@ import (
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/config"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/datasource"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/framework/grpc"
 	commonModels "github.com/Allen-Career-Institute/go-bff-commons/v1/framework/models/commons"
 	internal "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl"
 	apiMiddlewares "github.com/Allen-Career-Institute/go-bff-commons/v1/intrnl/middleware"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/httperr"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/logger"
+	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/propagation"
 	"github.com/Allen-Career-Institute/go-bff-commons/v1/pkg/utils"
 	"os"
 	"time"
@@ -94,7 +96,16 @@ func (e *DataSourceExecutor) ExecuteDataSource(c echo.Context) error {
 	connTimeout := time.Duration(e.ds.Timeout()) * time.Millisecond
 
 	toCtx, conCancel := utils.GetRequestCtxWithTimeout(c, connTimeout)
-	withMD := utils.AddAuthHeaderAsMetadata(toCtx, c)
+	// the metadata forwarded to the downstream services is captured by the AuthN middleware once the claims of the user
+	// are set, it is only captured here for the routes registered without it
+	md := propagation.FromContext(toCtx)
+	if md == nil {
+		md = propagation.Capture(c, propagation.PolicyFromConfig(e.cnf))
+	}
+
+	withMD := propagation.NewContext(utils.WithDataSource(toCtx, e.dsName), md)
+	// datasources tell whether a call was served with the last good response of its method by grpc.IsStale
+	withMD = grpc.WithStaleMarker(withMD)
 	c.SetRequest(c.Request().WithContext(withMD))
 
 	defer conCancel()
@@ -143,6 +154,13 @@ func (e *DataSourceExecutor) ExecuteDataSourceFromDS(c echo.Context) (*commonMod
 	}
 	filters := e.ds.GetFilters()
 
+	// the downstream calls are attributed to this datasource until it returns to the calling one, and it tells whether
+	// its own calls were served with a last good response by grpc.IsStale
+	req := c.Request()
+	c.SetRequest(req.WithContext(grpc.WithStaleMarker(utils.WithDataSource(req.Context(), e.dsName))))
+
+	defer c.SetRequest(req)
+
 	for _, filter := range filters {
 		err := filter(c)
 		if err != nil {